@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"feishu_bitable_demo/feishu"
+
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+)
+
+var createCommand = &command{
+	name:    "create",
+	summary: "创建示例多维表格（产品管理系统）并写入演示数据",
+	run:     runCreate,
+}
+
+// sampleProduct 示例产品数据
+type sampleProduct struct {
+	Name        string
+	Stock       float64
+	Price       float64
+	Status      string
+	Tags        []string
+	OnSale      bool
+	Description string
+}
+
+// fields 转换为记录字段
+func (p sampleProduct) fields() map[string]interface{} {
+	return map[string]interface{}{
+		"产品名称": feishu.CreateTextField(p.Name),
+		"库存数量": feishu.CreateNumberField(p.Stock),
+		"单价":   feishu.CreateNumberField(p.Price),
+		"状态":   feishu.CreateSingleSelectField(p.Status),
+		"标签":   feishu.CreateMultiSelectField(p.Tags),
+		"创建时间": feishu.CreateDateTimeFieldFromTime(time.Now()),
+		"是否上架": feishu.CreateCheckboxField(p.OnSale),
+		"产品描述": feishu.CreateTextField(p.Description),
+	}
+}
+
+var sampleProducts = []sampleProduct{
+	{"MacBook Pro 16", 50, 19999.00, "在售", []string{"热销", "推荐"}, true, "专业级笔记本电脑"},
+	{"iPad Air", 120, 4799.00, "在售", []string{"新品"}, true, "轻薄便携平板电脑"},
+	{"AirPods Pro 2", 200, 1899.00, "在售", []string{"热销"}, true, "主动降噪无线耳机"},
+	{"Apple Watch Ultra 2", 30, 6499.00, "预售", []string{"新品", "推荐"}, false, "户外运动智能手表"},
+	{"Mac Studio", 15, 14999.00, "在售", []string{"专业"}, true, "桌面级工作站"},
+}
+
+func runCreate(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	name := fs.String("name", "", "多维表格名称（默认 产品管理系统_<时间>）")
+	tableName := fs.String("table-name", "产品列表", "数据表名称")
+	s, err := parseFlags(ctx, fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+	if *name == "" {
+		*name = "产品管理系统_" + time.Now().Format("20060102_150405")
+	}
+	client := s.client
+
+	printBanner("🚀 飞书多维表格完整操作示例")
+
+	fmt.Println("📝 步骤 1: 创建多维表格和数据表")
+	appToken, tableID, err := client.CreateAppAndTableContext(ctx, *name, s.cfg.FolderToken, *tableName, productTableFields())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ 成功创建多维表格\n")
+	fmt.Printf("   App Token: %s\n", appToken)
+	fmt.Printf("   Table ID: %s\n\n", tableID)
+
+	fmt.Println("📝 步骤 2: 写入单条记录")
+	first := sampleProduct{"iPhone 15 Pro", 100, 7999.00, "在售", []string{"热销", "新品"}, true, "最新款 iPhone，搭载 A17 Pro 芯片，性能强劲"}
+	record, err := client.CreateRecordContext(ctx, appToken, tableID, first.fields())
+	if err != nil {
+		return err
+	}
+	recordID := record.ID
+	fmt.Printf("✅ 成功写入记录，ID: %s\n\n", recordID)
+
+	fmt.Println("📝 步骤 3: 批量写入记录")
+	records := make([]feishu.CreateRecordRequest, 0, len(sampleProducts))
+	for _, p := range sampleProducts {
+		records = append(records, feishu.CreateRecordRequest{Fields: p.fields()})
+	}
+	created, err := client.BatchCreateRecordsContext(ctx, appToken, tableID, records)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ 成功批量写入 %d 条记录\n\n", len(created.Records))
+
+	fmt.Println("📝 步骤 4: 读取记录")
+	record, err = client.GetRecordContext(ctx, appToken, tableID, recordID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("   记录内容: %+v\n", record.Fields)
+	fmt.Printf("✅ 成功读取记录\n\n")
+
+	fmt.Println("📝 步骤 5: 更新记录")
+	err = client.UpdateRecordContext(ctx, appToken, tableID, recordID, map[string]interface{}{
+		"库存数量": feishu.CreateNumberField(80),
+		"单价":   feishu.CreateNumberField(7499.00),
+		"状态":   feishu.CreateSingleSelectField("促销"),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ 成功更新记录\n\n")
+
+	fmt.Println("📝 步骤 6: 查询所有记录")
+	items, _, _, err := client.ListRecordsContext(ctx, appToken, tableID, 20, "")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("   共查询到 %d 条记录\n", len(items))
+	for i, item := range items {
+		if i == 3 { // 只显示前 3 条
+			fmt.Printf("   ... 还有 %d 条记录\n", len(items)-3)
+			break
+		}
+		fmt.Printf("   [%d] %s %+v\n", i+1, item.ID, item.Fields)
+	}
+	fmt.Printf("✅ 成功查询记录\n\n")
+
+	fmt.Println("📝 步骤 7: 删除测试记录")
+	if err := client.DeleteRecordContext(ctx, appToken, tableID, recordID); err != nil {
+		fmt.Printf("⚠️  删除记录失败: %v\n\n", err)
+	} else {
+		fmt.Printf("✅ 成功删除记录 ID: %s\n\n", recordID)
+	}
+
+	printBanner("🎉 所有操作完成！")
+	fmt.Printf("📊 多维表格访问地址：\n")
+	fmt.Printf("https://your-domain.feishu.cn/base/%s?table=%s\n", appToken, tableID)
+	return nil
+}
+
+// productTableFields 示例数据表的字段定义
+func productTableFields() []*larkbitable.AppTableCreateHeader {
+	return feishu.Headers(productFieldSpecs()...)
+}
+
+// productFieldSpecs 示例数据表的字段
+func productFieldSpecs() []feishu.FieldSpec {
+	return []feishu.FieldSpec{
+		{Name: "产品名称", Type: feishu.FieldTypeText},
+		{Name: "库存数量", Type: feishu.FieldTypeNumber, Property: feishu.NumberProperty(feishu.NumberFormatInteger)},
+		{Name: "单价", Type: feishu.FieldTypeNumber, UIType: feishu.UITypeCurrency, Property: feishu.CurrencyProperty("CNY", feishu.NumberFormatDecimal2)},
+		{Name: "状态", Type: feishu.FieldTypeSingleSelect, Property: feishu.SelectOptions("在售", "预售", "促销", "下架")},
+		{Name: "标签", Type: feishu.FieldTypeMultiSelect, Property: feishu.SelectOptions("热销", "新品", "推荐", "专业")},
+		{Name: "创建时间", Type: feishu.FieldTypeDate, Property: feishu.DateProperty(feishu.DateFormatDateTime, false)},
+		{Name: "是否上架", Type: feishu.FieldTypeCheckbox},
+		{Name: "产品描述", Type: feishu.FieldTypeText},
+	}
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"feishu_bitable_demo/feishu"
+)
+
+var docsCommand = &command{
+	name:    "docs",
+	summary: "创建和读取飞书云文档",
+	children: []*command{
+		{name: "create", summary: "创建云文档，可同时写入一段文本", run: runDocsCreate},
+		{name: "get", args: "<document_id|链接>", summary: "获取云文档信息", run: runDocsGet},
+		{name: "content", args: "<document_id|链接>", summary: "输出云文档的纯文本内容", run: runDocsContent},
+		{name: "blocks", args: "<document_id|链接>", summary: "列出云文档的块", run: runDocsBlocks},
+		{name: "demo", summary: "演示创建云文档、写入文本并读取", run: runDocsDemo},
+		{name: "markdown", summary: "演示以文本块写入 Markdown 内容", run: runDocsMarkdown},
+		{name: "styles", summary: "演示标题、列表、代码块、引用、待办等块样式", run: runDocsStyles},
+	},
+}
+
+func runDocsCreate(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	title := fs.String("title", "", "文档标题（必填）")
+	text := fs.String("text", "", "写入文档的文本内容（可选）")
+	s, err := parseFlags(ctx, fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+	if *title == "" {
+		return usageErrorf("缺少 -title 参数")
+	}
+
+	documentID, err := createDocument(ctx, s, *title)
+	if err != nil {
+		return err
+	}
+	if *text != "" {
+		if _, err := s.client.CreateDocumentBlockContext(ctx, documentID, pageBlockID(documentID), -1, []*feishu.Block{feishu.CreateTextBlock(*text)}); err != nil {
+			return err
+		}
+	}
+	fmt.Println(documentID)
+	return nil
+}
+
+func runDocsGet(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出")
+	s, err := parseFlags(ctx, fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 1); err != nil {
+		return err
+	}
+
+	id, err := resolveDocumentID(ctx, s, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.GetDocumentContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	doc := resp.Data.Document
+
+	if *asJSON {
+		return printJSON(doc)
+	}
+	fmt.Printf("文档 ID: %s\n", feishu.StringValue(doc.DocumentId))
+	fmt.Printf("标题: %s\n", feishu.StringValue(doc.Title))
+	if doc.RevisionId != nil {
+		fmt.Printf("版本: %d\n", *doc.RevisionId)
+	}
+	return nil
+}
+
+func runDocsContent(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	s, err := parseFlags(ctx, fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 1); err != nil {
+		return err
+	}
+
+	id, err := resolveDocumentID(ctx, s, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	content, err := rawContent(ctx, s, id)
+	if err != nil {
+		return err
+	}
+	fmt.Print(content)
+	return nil
+}
+
+func runDocsBlocks(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出")
+	s, err := parseFlags(ctx, fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 1); err != nil {
+		return err
+	}
+
+	id, err := resolveDocumentID(ctx, s, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.ListDocumentBlocksContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(resp.Data.Items)
+	}
+	for _, block := range resp.Data.Items {
+		fmt.Printf("%s\t%d\n", feishu.StringValue(block.BlockId), blockType(block))
+	}
+	return nil
+}
+
+// runDocsDemo 创建云文档、写入一段文本，再读取文档信息、块和纯文本内容
+func runDocsDemo(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	s, err := parseFlags(ctx, fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+	client := s.client
+
+	printBanner("🚀 飞书云文档操作示例程序")
+
+	fmt.Println("📝 步骤 1: 创建云文档")
+	documentID, err := createDocument(ctx, s, fmt.Sprintf("测试云文档 - %s", time.Now().Format("2006-01-02 15:04:05")))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ 成功创建云文档，Document ID: %s\n\n", documentID)
+
+	fmt.Println("📝 步骤 2: 写入自定义信息到云文档")
+	info := "本云文档由 feishu 命令行工具自动生成，演示云文档写入功能。\n\n可在此处记录项目说明、操作日志、或其他自定义内容。"
+	if _, err := client.CreateDocumentBlockContext(ctx, documentID, pageBlockID(documentID), -1, []*feishu.Block{feishu.CreateTextBlock(info)}); err != nil {
+		fmt.Printf("⚠️  写入信息失败: %v\n", err)
+		fmt.Printf("  💡 请检查应用是否有云文档编辑权限\n\n")
+	} else {
+		fmt.Printf("✅ 已成功写入自定义信息到云文档\n\n")
+	}
+
+	fmt.Println("📝 步骤 3: 获取云文档信息")
+	docResp, err := client.GetDocumentContext(ctx, documentID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  📄 文档标题: %s\n", feishu.StringValue(docResp.Data.Document.Title))
+	fmt.Printf("✅ 成功获取云文档信息\n\n")
+
+	fmt.Println("📝 步骤 4: 获取云文档所有块")
+	blocksResp, err := client.ListDocumentBlocksContext(ctx, documentID)
+	if err != nil {
+		return err
+	}
+	for _, block := range blocksResp.Data.Items {
+		fmt.Printf("  📦 块 ID: %s, 类型: %d\n", feishu.StringValue(block.BlockId), blockType(block))
+	}
+	fmt.Printf("✅ 成功获取文档块，共 %d 个块\n\n", len(blocksResp.Data.Items))
+
+	fmt.Println("📝 步骤 5: 获取云文档纯文本内容")
+	content, err := rawContent(ctx, s, documentID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  📄 文档内容（原始）:\n  %s\n", preview(content, 200))
+	fmt.Printf("✅ 成功获取文档内容\n\n")
+
+	printBanner("🎉 所有云文档操作测试完成！")
+	fmt.Printf("📄 文档链接: https://example.feishu.cn/docx/%s\n", documentID)
+	return nil
+}
+
+// runDocsMarkdown 以普通文本块写入 Markdown 源文本（飞书不会渲染其中的格式）
+func runDocsMarkdown(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	s, err := parseFlags(ctx, fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+	client := s.client
+
+	printBanner("📝 飞书云文档 Markdown 写入示例")
+
+	fmt.Println("📝 步骤 1: 创建云文档")
+	documentID, err := createDocument(ctx, s, fmt.Sprintf("Markdown 示例文档 - %s", time.Now().Format("2006-01-02 15:04:05")))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ 成功创建云文档，Document ID: %s\n\n", documentID)
+
+	fmt.Println("📝 步骤 2: 写入 Markdown 格式内容")
+	texts := []string{
+		"# Markdown 格式示例\n\n这是一个展示如何在飞书云文档中写入 Markdown 格式内容的示例。",
+		"\n## 文本格式\n\n**粗体文本** 和 *斜体文本* 以及 `代码文本`。\n\n你也可以使用 ~~删除线~~ 和 __下划线__。",
+		"\n## 列表示例\n\n无序列表：\n- 第一项\n- 第二项\n  - 子项 2.1\n  - 子项 2.2\n- 第三项",
+		"\n有序列表：\n1. 第一步\n2. 第二步\n3. 第三步",
+		"\n## 链接和引用\n\n访问 [飞书开放平台](https://open.feishu.cn) 了解更多。\n\n> 这是一个引用块\n> 可以包含多行内容",
+		"\n## 代码示例\n\n内联代码：`fmt.Println(\"Hello World\")`\n\n代码块：\n```go\npackage main\n\nimport \"fmt\"\n\nfunc main() {\n    fmt.Println(\"Hello, Feishu!\")\n}\n```",
+		"\n## 表格\n\n| 功能 | 状态 | 说明 |\n|------|------|------|\n| 创建文档 | ✅ | 已实现 |\n| 写入内容 | ✅ | 已实现 |\n| 读取内容 | ✅ | 已实现 |",
+		"\n## 任务清单\n\n- [x] 创建云文档\n- [x] 写入 Markdown 内容\n- [x] 验证文档内容\n- [ ] 添加更多功能",
+		"\n---\n\n📅 生成时间：" + time.Now().Format("2006-01-02 15:04:05"),
+	}
+	blocks := make([]*feishu.Block, 0, len(texts))
+	for _, text := range texts {
+		blocks = append(blocks, feishu.CreateTextBlock(text))
+	}
+	resp, err := client.CreateDocumentBlockContext(ctx, documentID, pageBlockID(documentID), -1, blocks)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  📦 成功写入 %d 个内容块\n", len(resp.Data.Children))
+	fmt.Printf("✅ 成功写入 Markdown 格式内容\n\n")
+
+	fmt.Println("📝 步骤 3: 验证文档内容")
+	content, err := rawContent(ctx, s, documentID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  📝 文档内容长度: %d 字符\n", len(content))
+	fmt.Printf("  📄 内容预览:\n%s\n", preview(content, 300))
+	fmt.Printf("✅ 内容验证完成\n\n")
+
+	printBanner("🎉 Markdown 内容写入完成！")
+	fmt.Printf("📄 文档链接: https://example.feishu.cn/docx/%s\n", documentID)
+	return nil
+}
+
+// runDocsStyles 使用不同类型的块写入标题、样式文本、列表、代码块、引用和待办事项
+func runDocsStyles(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	s, err := parseFlags(ctx, fs, args)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+
+	printBanner("🚀 飞书云文档高级 Markdown 样式示例")
+
+	fmt.Println("📝 步骤 1: 创建云文档")
+	documentID, err := createDocument(ctx, s, fmt.Sprintf("Markdown 样式示例 - %s", time.Now().Format("2006-01-02 15:04:05")))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ 成功创建云文档，Document ID: %s\n\n", documentID)
+
+	fmt.Println("📝 步骤 2: 写入各种 Markdown 块到文档")
+	steps := []struct {
+		name   string
+		blocks []*feishu.Block
+	}{
+		{"一级标题", []*feishu.Block{feishu.CreateHeading1Block("📚 Markdown 样式完整指南")}},
+		{"带样式的文本", []*feishu.Block{feishu.CreateStyledTextBlock(
+			"这是一段演示文本，包含 ",
+			feishu.BoldText("加粗"),
+			feishu.PlainText("、"),
+			feishu.ItalicText("斜体"),
+			feishu.PlainText("、"),
+			feishu.UnderlineText("下划线"),
+			feishu.PlainText("、"),
+			feishu.StrikethroughText("删除线"),
+			feishu.PlainText("、"),
+			feishu.InlineCodeText("代码"),
+			feishu.PlainText("、"),
+			feishu.ColoredText("红色文本", 1),
+			feishu.PlainText(" 和 "),
+			feishu.LinkText("超链接", "https://open.feishu.cn"),
+			feishu.PlainText("。"),
+		)}},
+		{"二级标题", []*feishu.Block{feishu.CreateHeading2Block("🎨 文本样式示例")}},
+		{"无序列表", []*feishu.Block{
+			feishu.CreateBulletBlock("第一个列表项"),
+			feishu.CreateBulletBlock("第二个列表项"),
+			feishu.CreateBulletBlock("第三个列表项"),
+		}},
+		{"有序列表", []*feishu.Block{
+			feishu.CreateOrderedBlock("第一步：初始化客户端", 1),
+			feishu.CreateOrderedBlock("第二步：创建文档", 2),
+			feishu.CreateOrderedBlock("第三步：写入内容", 3),
+		}},
+		{"代码块", []*feishu.Block{feishu.CreateCodeBlock("package main\n\nimport \"fmt\"\n\nfunc main() {\n    fmt.Println(\"Hello, Feishu!\")\n}", 22)}}, // 22 = Go
+		{"引用块", []*feishu.Block{feishu.CreateQuoteBlock("这是一段重要的引用内容，用于强调或引述。")}},
+		{"待办事项", []*feishu.Block{
+			feishu.CreateTodoBlock("完成文档编写", false),
+			feishu.CreateTodoBlock("代码审查", false),
+			feishu.CreateTodoBlock("部署上线", false),
+		}},
+	}
+	for i, step := range steps {
+		fmt.Printf("  ✏️  2.%d: 写入%s\n", i+1, step.name)
+		if _, err := s.client.CreateDocumentBlockContext(ctx, documentID, pageBlockID(documentID), -1, step.blocks); err != nil {
+			// 单个块写入失败时继续写入其他块，便于排查哪种块不受支持
+			if ctx.Err() != nil {
+				return err
+			}
+			fmt.Printf("⚠️  写入%s失败: %v\n", step.name, err)
+		}
+	}
+	fmt.Printf("✅ 所有 Markdown 块已写入\n\n")
+
+	fmt.Println("📝 步骤 3: 验证文档内容")
+	content, err := rawContent(ctx, s, documentID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  📄 文档包含 %d 个字符\n", len(content))
+	fmt.Printf("✅ 文档内容验证成功\n\n")
+
+	printBanner("🎉 Markdown 样式示例完成！")
+	fmt.Printf("📄 文档链接: https://example.feishu.cn/docx/%s\n", documentID)
+	return nil
+}
+
+// createDocument 在配置的文件夹中创建云文档，返回 document_id
+func createDocument(ctx context.Context, s *session, title string) (string, error) {
+	resp, err := s.client.CreateDocumentContext(ctx, title, s.cfg.FolderToken)
+	if err != nil {
+		return "", err
+	}
+	if resp.Data == nil || resp.Data.Document == nil || resp.Data.Document.DocumentId == nil {
+		return "", fmt.Errorf("创建文档的响应数据为空")
+	}
+	return *resp.Data.Document.DocumentId, nil
+}
+
+// rawContent 获取云文档的纯文本内容
+func rawContent(ctx context.Context, s *session, documentID string) (string, error) {
+	resp, err := s.client.GetDocumentRawContentContext(ctx, documentID)
+	if err != nil {
+		return "", err
+	}
+	if resp.Data == nil || resp.Data.Content == nil {
+		return "", fmt.Errorf("文档内容的响应数据为空")
+	}
+	return *resp.Data.Content, nil
+}
+
+// pageBlockID 文档根节点（页面块）的 block_id，与 document_id 相同
+func pageBlockID(documentID string) string {
+	return documentID
+}
+
+// blockType 返回块的类型编号，缺少类型时返回 0
+func blockType(block *feishu.Block) int {
+	if block.BlockType == nil {
+		return 0
+	}
+	return *block.BlockType
+}
+
+// preview 截取内容的前 n 个字符用于展示
+func preview(content string, n int) string {
+	runes := []rune(content)
+	if len(runes) <= n {
+		return content
+	}
+	return string(runes[:n]) + "..."
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"feishu_bitable_demo/config"
+	"feishu_bitable_demo/feishu"
+)
+
+// maxBlocksPerRequest 单次创建文档块接口最多写入的子块数量
+const maxBlocksPerRequest = 50
+
+var exportCommand = &command{
+	name:    "export",
+	summary: "将数据表中的记录导出为云文档报告",
+	run:     runExport,
+}
+
+func runExport(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	title := fs.String("title", "", "报告文档标题（默认 数据报告 - <时间>）")
+	limit := fs.Int("limit", 0, "最多导出的记录数，0 表示全部")
+	s, err := parseFlags(ctx, fs, args, config.AppToken, config.TableID)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+	if *limit < 0 {
+		return usageErrorf("-limit 不能为负数")
+	}
+	if *title == "" {
+		*title = fmt.Sprintf("数据报告 - %s", time.Now().Format("2006-01-02 15:04:05"))
+	}
+
+	printBanner("📊 飞书数据导出：多维表格 → 云文档")
+
+	fmt.Println("📝 步骤 1: 从多维表格读取数据")
+	records, err := fetchRecords(ctx, s, *limit)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ 成功读取 %d 条记录\n\n", len(records))
+
+	fmt.Println("📝 步骤 2: 创建云文档")
+	documentID, err := createDocument(ctx, s, *title)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ 成功创建云文档，Document ID: %s\n\n", documentID)
+
+	fmt.Println("📝 步骤 3: 将数据写入云文档")
+	blocks := reportBlocks(s.cfg, records)
+	for start := 0; start < len(blocks); start += maxBlocksPerRequest {
+		end := min(start+maxBlocksPerRequest, len(blocks))
+		if _, err := s.client.CreateDocumentBlockContext(ctx, documentID, pageBlockID(documentID), -1, blocks[start:end]); err != nil {
+			return fmt.Errorf("写入第 %d-%d 个块失败: %w", start+1, end, err)
+		}
+	}
+	fmt.Printf("✅ 成功写入 %d 个内容块\n\n", len(blocks))
+
+	printBanner("🎉 数据导出完成！")
+	fmt.Printf("📄 文档链接: https://example.feishu.cn/docx/%s\n", documentID)
+	return nil
+}
+
+// fetchRecords 读取数据表中的记录，limit 为 0 时读取全部
+func fetchRecords(ctx context.Context, s *session, limit int) ([]*feishu.Record, error) {
+	var records []*feishu.Record
+	for record, err := range s.client.AllRecordsContext(ctx, s.cfg.AppToken, s.cfg.TableID, feishu.MaxPageSize) {
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+		if limit > 0 && len(records) == limit {
+			break
+		}
+	}
+	return records, nil
+}
+
+// reportBlocks 生成报告内容：标题、概要和每条记录一个列表项（以 record_id 开头）
+func reportBlocks(cfg *config.Feishu, records []*feishu.Record) []*feishu.Block {
+	blocks := []*feishu.Block{
+		feishu.CreateHeading1Block("数据概览"),
+		feishu.CreateTextBlock(fmt.Sprintf("数据来源: app_token=%s, table_id=%s\n导出时间: %s\n记录数: %d",
+			cfg.AppToken, cfg.TableID, time.Now().Format("2006-01-02 15:04:05"), len(records))),
+		feishu.CreateHeading2Block("记录明细"),
+	}
+	for _, record := range records {
+		blocks = append(blocks, feishu.CreateBulletBlock(record.ID+"｜"+formatFields(record.Fields)))
+	}
+	return blocks
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"feishu_bitable_demo/config"
+	"feishu_bitable_demo/feishu"
+
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+)
+
+var fieldsCommand = &command{
+	name:    "fields",
+	summary: "查看和管理数据表的字段（列）",
+	children: []*command{
+		{name: "list", summary: "列出数据表的字段及其类型、属性", run: runFieldsList},
+		{name: "create", summary: "在数据表末尾新增字段", run: runFieldsCreate},
+		{name: "update", args: "<字段>", summary: "修改字段的名称、类型或属性，字段可以是 field_id 或字段名", run: runFieldsUpdate},
+		{name: "delete", args: "<字段>...", summary: "删除字段及其所有值，字段可以是 field_id 或字段名", run: runFieldsDelete},
+	},
+}
+
+func runFieldsList(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出（包含完整的字段属性）")
+	s, err := parseFlags(ctx, fs, args, config.AppToken, config.TableID)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+
+	fields, err := s.client.ListFieldsContext(ctx, s.cfg.AppToken, s.cfg.TableID)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(fields)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FIELD_ID\t名称\t类型\t属性")
+	for _, f := range fields {
+		typeName := f.Type.String()
+		if f.UIType != "" {
+			typeName += "/" + f.UIType
+		}
+		name := f.Name
+		if f.IsPrimary {
+			name += " *"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", f.ID, name, typeName, propertySummary(f.Property))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("共 %d 个字段（* 为索引列）\n", len(fields))
+	return nil
+}
+
+// fieldSpecFlags create、update 命令共用的字段定义参数
+type fieldSpecFlags struct {
+	name        string
+	fieldType   string
+	uiType      string
+	options     string
+	property    string
+	description string
+}
+
+func (f *fieldSpecFlags) bind(fs *flag.FlagSet) {
+	fs.StringVar(&f.name, "name", "", "字段名")
+	fs.StringVar(&f.fieldType, "type", "", "字段类型，英文名称、中文名称或类型编号，如 single_select、单选、3")
+	fs.StringVar(&f.uiType, "ui-type", "", "界面展示类型，如 Currency、Progress、Rating、Email")
+	fs.StringVar(&f.options, "options", "", "单选、多选字段的选项，以逗号分隔，如 在售,下架")
+	fs.StringVar(&f.property, "property", "", `字段属性 JSON，格式见飞书字段编辑指南，如 '{"formatter":"0.00"}'`)
+	fs.StringVar(&f.description, "description", "", "字段描述")
+}
+
+// apply 将命令行中设置过的参数写入 spec
+func (f *fieldSpecFlags) apply(fs *flag.FlagSet, spec *feishu.FieldSpec) error {
+	if f.options != "" && f.property != "" {
+		return usageErrorf("-options 和 -property 不能同时使用")
+	}
+	var err error
+	fs.Visit(func(fl *flag.Flag) {
+		if err != nil {
+			return
+		}
+		switch fl.Name {
+		case "name":
+			spec.Name = f.name
+		case "type":
+			var e error
+			if spec.Type, e = feishu.ParseFieldType(f.fieldType); e != nil {
+				err = usageErrorf("-type: %v", e)
+			}
+		case "ui-type":
+			spec.UIType = f.uiType
+		case "options":
+			spec.Property = feishu.SelectOptions(splitList(f.options)...)
+		case "property":
+			spec.Property = new(larkbitable.AppTableFieldProperty)
+			if e := json.Unmarshal([]byte(f.property), spec.Property); e != nil {
+				err = usageErrorf("-property 不是合法的字段属性 JSON: %v", e)
+			}
+		case "description":
+			spec.Description = f.description
+		}
+	})
+	return err
+}
+
+func runFieldsCreate(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	var specFlags fieldSpecFlags
+	specFlags.bind(fs)
+	s, err := parseFlags(ctx, fs, args, config.AppToken, config.TableID)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+	if specFlags.name == "" || specFlags.fieldType == "" {
+		return usageErrorf("需要指定 -name 和 -type")
+	}
+	var spec feishu.FieldSpec
+	if err := specFlags.apply(fs, &spec); err != nil {
+		return err
+	}
+
+	field, err := s.client.CreateFieldContext(ctx, s.cfg.AppToken, s.cfg.TableID, spec)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ 已创建字段 %s（%s，%s）\n", field.Name, field.ID, field.Type)
+	return nil
+}
+
+func runFieldsUpdate(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	var specFlags fieldSpecFlags
+	specFlags.bind(fs)
+	s, err := parseFlags(ctx, fs, args, config.AppToken, config.TableID)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 1); err != nil {
+		return err
+	}
+
+	fields, err := s.client.ListFieldsContext(ctx, s.cfg.AppToken, s.cfg.TableID)
+	if err != nil {
+		return err
+	}
+	current, err := findField(fields, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	// 更新接口整体替换字段定义，未指定的参数沿用当前的值；
+	// 修改类型时不沿用原有的属性，单选、多选之间转换时保留选项
+	spec := feishu.FieldSpec{
+		Name:        current.Name,
+		Type:        current.Type,
+		UIType:      current.UIType,
+		Property:    current.Property,
+		Description: current.Description,
+	}
+	if err := specFlags.apply(fs, &spec); err != nil {
+		return err
+	}
+	if spec.Type != current.Type {
+		if specFlags.uiType == "" {
+			spec.UIType = ""
+		}
+		if specFlags.options == "" && specFlags.property == "" && !(isSelect(spec.Type) && isSelect(current.Type)) {
+			spec.Property = nil
+		}
+	}
+
+	field, err := s.client.UpdateFieldContext(ctx, s.cfg.AppToken, s.cfg.TableID, current.ID, spec)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ 已更新字段 %s（%s，%s）\n", field.Name, field.ID, field.Type)
+	return nil
+}
+
+func runFieldsDelete(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	s, err := parseFlags(ctx, fs, args, config.AppToken, config.TableID)
+	if err != nil {
+		return err
+	}
+	if err := minArgs(fs, 1); err != nil {
+		return err
+	}
+
+	fields, err := s.client.ListFieldsContext(ctx, s.cfg.AppToken, s.cfg.TableID)
+	if err != nil {
+		return err
+	}
+	targets := make([]*feishu.Field, 0, fs.NArg())
+	for _, arg := range fs.Args() {
+		field, err := findField(fields, arg)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, field)
+	}
+	for _, field := range targets {
+		if err := s.client.DeleteFieldContext(ctx, s.cfg.AppToken, s.cfg.TableID, field.ID); err != nil {
+			return err
+		}
+		fmt.Printf("✅ 已删除字段 %s（%s）\n", field.Name, field.ID)
+	}
+	return nil
+}
+
+// isSelect 判断是否为单选或多选字段
+func isSelect(t feishu.FieldType) bool {
+	return t == feishu.FieldTypeSingleSelect || t == feishu.FieldTypeMultiSelect
+}
+
+// findField 按 field_id 或字段名查找字段
+func findField(fields []*feishu.Field, arg string) (*feishu.Field, error) {
+	for _, f := range fields {
+		if f.ID == arg || f.Name == arg {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("数据表中没有字段 %s", arg)
+}
+
+// splitList 将逗号分隔的列表拆分为去除空白后的非空元素
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// propertySummary 将字段属性概括为一行，如选项列表、显示格式、关联的数据表、公式
+func propertySummary(p *larkbitable.AppTableFieldProperty) string {
+	if p == nil {
+		return ""
+	}
+	var parts []string
+	if len(p.Options) > 0 {
+		names := make([]string, 0, len(p.Options))
+		for _, option := range p.Options {
+			names = append(names, feishu.StringValue(option.Name))
+		}
+		parts = append(parts, "选项: "+strings.Join(names, "/"))
+	}
+	if p.CurrencyCode != nil {
+		parts = append(parts, "货币: "+*p.CurrencyCode)
+	}
+	if p.Formatter != nil && *p.Formatter != "" {
+		parts = append(parts, "格式: "+*p.Formatter)
+	}
+	if p.DateFormatter != nil {
+		parts = append(parts, "日期格式: "+*p.DateFormatter)
+	}
+	if p.TableId != nil {
+		parts = append(parts, "关联: "+*p.TableId)
+	}
+	if p.FormulaExpression != nil {
+		parts = append(parts, "公式: "+*p.FormulaExpression)
+	}
+	if p.Multiple != nil && *p.Multiple {
+		parts = append(parts, "多选")
+	}
+	return strings.Join(parts, "，")
+}
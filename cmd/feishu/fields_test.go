@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"feishu_bitable_demo/feishu"
+	"feishu_bitable_demo/feishu/fakeserver"
+)
+
+// setupCLI 创建模拟服务和数据表，并通过环境变量让命令连接到模拟服务
+func setupCLI(t *testing.T) (*feishu.MultiTableClient, string, string) {
+	t.Helper()
+	srv := fakeserver.New()
+	t.Cleanup(srv.Close)
+	client := srv.NewClient()
+	appToken, tableID, err := client.CreateAppAndTable("测试", "", "测试表", feishu.Headers(
+		feishu.FieldSpec{Name: "名称", Type: feishu.FieldTypeText},
+		feishu.FieldSpec{Name: "状态", Type: feishu.FieldTypeSingleSelect, Property: feishu.SelectOptions("在售", "下架")},
+	))
+	if err != nil {
+		t.Fatalf("CreateAppAndTable: %v", err)
+	}
+	t.Setenv("FEISHU_PROFILE", "")
+	t.Setenv("FEISHU_BASE_URL", srv.URL)
+	t.Setenv("FEISHU_APP_ID", "cli_test")
+	t.Setenv("FEISHU_APP_SECRET", "secret")
+	t.Setenv("FEISHU_APP_TOKEN", appToken)
+	t.Setenv("FEISHU_TABLE_ID", tableID)
+	return client, appToken, tableID
+}
+
+// runCLI 执行命令并检查退出码
+func runCLI(t *testing.T, want int, args ...string) {
+	t.Helper()
+	var stderr bytes.Buffer
+	if code := run(context.Background(), args, &stderr); code != want {
+		t.Fatalf("feishu %v: exit code %d, want %d\n%s", args, code, want, stderr.String())
+	}
+}
+
+func fieldByName(t *testing.T, client *feishu.MultiTableClient, appToken, tableID, name string) *feishu.Field {
+	t.Helper()
+	fields, err := client.ListFields(appToken, tableID)
+	if err != nil {
+		t.Fatalf("ListFields: %v", err)
+	}
+	for _, f := range fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func optionNames(f *feishu.Field) []string {
+	var names []string
+	if f.Property != nil {
+		for _, option := range f.Property.Options {
+			names = append(names, feishu.StringValue(option.Name))
+		}
+	}
+	return names
+}
+
+func TestFieldsCreateAndDelete(t *testing.T) {
+	client, appToken, tableID := setupCLI(t)
+
+	runCLI(t, exitOK, "fields", "create", "-name", "优先级", "-type", "single_select", "-options", "高, 中,低", "-description", "处理顺序")
+	f := fieldByName(t, client, appToken, tableID, "优先级")
+	if f == nil || f.Type != feishu.FieldTypeSingleSelect || f.Description != "处理顺序" {
+		t.Fatalf("created field = %+v", f)
+	}
+	if got := optionNames(f); len(got) != 3 || got[1] != "中" {
+		t.Errorf("options = %q, want 高/中/低", got)
+	}
+
+	runCLI(t, exitOK, "fields", "create", "-name", "折扣", "-type", "数字", "-property", `{"formatter":"0.00"}`)
+	if f := fieldByName(t, client, appToken, tableID, "折扣"); f == nil || f.Type != feishu.FieldTypeNumber || feishu.StringValue(f.Property.Formatter) != "0.00" {
+		t.Errorf("created field = %+v", f)
+	}
+
+	runCLI(t, exitUsage, "fields", "create", "-name", "备注", "-type", "string")
+	runCLI(t, exitUsage, "fields", "create", "-name", "备注")
+
+	runCLI(t, exitOK, "fields", "delete", "优先级", "折扣")
+	if fieldByName(t, client, appToken, tableID, "优先级") != nil || fieldByName(t, client, appToken, tableID, "折扣") != nil {
+		t.Error("fields not deleted")
+	}
+	runCLI(t, exitFailure, "fields", "delete", "不存在")
+}
+
+func TestFieldsUpdate(t *testing.T) {
+	client, appToken, tableID := setupCLI(t)
+	status := fieldByName(t, client, appToken, tableID, "状态")
+
+	// 只改名称时保留类型和选项
+	runCLI(t, exitOK, "fields", "update", "-name", "销售状态", "-description", "当前状态", status.ID)
+	f := fieldByName(t, client, appToken, tableID, "销售状态")
+	if f == nil || f.Type != feishu.FieldTypeSingleSelect || len(optionNames(f)) != 2 || f.Description != "当前状态" {
+		t.Fatalf("renamed field = %+v", f)
+	}
+
+	// 单选、多选之间转换时保留选项
+	runCLI(t, exitOK, "fields", "update", "-type", "多选", "销售状态")
+	f = fieldByName(t, client, appToken, tableID, "销售状态")
+	if f.Type != feishu.FieldTypeMultiSelect || len(optionNames(f)) != 2 {
+		t.Fatalf("field after single → multi select = %+v", f)
+	}
+
+	// 改为其他类型时清空原有的属性
+	runCLI(t, exitOK, "fields", "update", "-type", "text", "销售状态")
+	f = fieldByName(t, client, appToken, tableID, "销售状态")
+	if f.Type != feishu.FieldTypeText || len(optionNames(f)) != 0 || f.Description != "当前状态" {
+		t.Fatalf("field after multi select → text = %+v", f)
+	}
+
+	// 改类型的同时指定属性
+	runCLI(t, exitOK, "fields", "update", "-type", "number", "-ui-type", feishu.UITypeProgress, "-property", `{"formatter":"0%","min":0,"max":1}`, "销售状态")
+	f = fieldByName(t, client, appToken, tableID, "销售状态")
+	if f.Type != feishu.FieldTypeNumber || f.UIType != feishu.UITypeProgress || feishu.StringValue(f.Property.Formatter) != "0%" {
+		t.Fatalf("field after text → progress = %+v", f)
+	}
+
+	runCLI(t, exitUsage, "fields", "update", "-options", "a", "-property", "{}", "销售状态")
+	runCLI(t, exitUsage, "fields", "update", "-property", "not json", "销售状态")
+}
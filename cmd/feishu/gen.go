@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"feishu_bitable_demo/config"
+	"feishu_bitable_demo/feishu"
+	"feishu_bitable_demo/feishu/fakeserver"
+
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+)
+
+var genCommand = &command{
+	name:    "gen",
+	summary: "根据数据表结构生成代码",
+	children: []*command{
+		{name: "structs", summary: "根据数据表的字段生成带 bitable 标签的 Go 结构体", run: runGenStructs},
+	},
+}
+
+func runGenStructs(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	pkg := fs.String("package", "model", "生成代码的包名")
+	typeName := fs.String("type", "Record", "生成的结构体名称")
+	output := fs.String("o", "", "输出文件（默认输出到标准输出）")
+	var names stringList
+	fs.Var(&names, "rename", "指定字段对应的 Go 字段名，如 -rename 产品名称=Name（可重复）；\n未指定时中文等非 ASCII 字段名按列序生成 Field1、Field2……")
+	fake := fs.Bool("fake", false, "不连接飞书，基于内存中的模拟服务和示例产品表生成，用于测试")
+	var opts config.Options
+	opts.BindFlags(fs)
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+	if !token.IsIdentifier(*pkg) {
+		return usageErrorf("-package 不是合法的包名: %s", *pkg)
+	}
+	if !token.IsIdentifier(*typeName) || !token.IsExported(*typeName) {
+		return usageErrorf("-type 必须是导出的 Go 标识符: %s", *typeName)
+	}
+	goNames := make(map[string]string, len(names))
+	for _, name := range names {
+		field, goName, ok := strings.Cut(name, "=")
+		if !ok || field == "" || !token.IsIdentifier(goName) || !token.IsExported(goName) {
+			return usageErrorf("-rename 的格式应为 字段名=导出的 Go 字段名: %s", name)
+		}
+		goNames[field] = goName
+	}
+
+	var (
+		client            *feishu.MultiTableClient
+		appToken, tableID string
+	)
+	if *fake {
+		srv := fakeserver.New()
+		defer srv.Close()
+		client = srv.NewClient()
+		var err error
+		appToken, tableID, err = client.CreateAppAndTableContext(ctx, "示例多维表格", "", "产品列表", genSampleFields())
+		if err != nil {
+			return err
+		}
+	} else {
+		s, err := newSession(ctx, opts, config.AppToken, config.TableID)
+		if err != nil {
+			return err
+		}
+		client, appToken, tableID = s.client, s.cfg.AppToken, s.cfg.TableID
+	}
+
+	fields, err := client.ListFieldsContext(ctx, appToken, tableID)
+	if err != nil {
+		return err
+	}
+	tableName := tableID
+	tables, err := client.ListTablesContext(ctx, appToken)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if feishu.StringValue(table.TableId) == tableID {
+			tableName = feishu.StringValue(table.Name)
+		}
+	}
+
+	src, err := generateStructs(fs.Output(), *pkg, *typeName, tableName, tableID, fields, goNames)
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err := os.Stdout.Write(src)
+		return err
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("✅ 已生成 %s（%d 个字段）\n", *output, len(fields))
+	return nil
+}
+
+// genSampleFields -fake 模式使用的示例数据表：示例产品表加上人员、超链接、附件和只读字段
+func genSampleFields() []*larkbitable.AppTableCreateHeader {
+	expression := `bitable::$table[tblxxxxxx].$field[库存数量]*bitable::$table[tblxxxxxx].$field[单价]`
+	return feishu.Headers(append(productFieldSpecs(),
+		feishu.FieldSpec{Name: "负责人", Type: feishu.FieldTypeUser, Property: feishu.UserProperty(true)},
+		feishu.FieldSpec{Name: "官网", Type: feishu.FieldTypeURL},
+		feishu.FieldSpec{Name: "附件", Type: feishu.FieldTypeAttachment},
+		feishu.FieldSpec{Name: "库存金额", Type: feishu.FieldTypeFormula, Property: feishu.FormulaProperty(expression, feishu.NumberFormatDecimal2)},
+		feishu.FieldSpec{Name: "创建人", Type: feishu.FieldTypeCreatedUser},
+		feishu.FieldSpec{Name: "编号", Type: feishu.FieldTypeAutoNumber, Property: feishu.AutoNumberProperty()},
+	)...)
+}
+
+// genField 生成代码中的一个结构体字段
+type genField struct {
+	field      *feishu.Field
+	goName     string
+	goType     string
+	readOnly   bool
+	optionType string   // 单选、多选字段的选项类型名
+	options    []string // 选项名称
+}
+
+// generateStructs 根据字段列表生成结构体及单选、多选字段的选项常量，goNames 为字段名到 Go 字段名的映射。
+// 无法映射的字段和按列序命名的字段提示输出到 warn
+func generateStructs(warn io.Writer, pkg, typeName, tableName, tableID string, fields []*feishu.Field, goNames map[string]string) ([]byte, error) {
+	used := map[string]bool{"RecordID": true}
+	unique := func(base string) string {
+		name := base
+		for i := 2; used[name]; i++ {
+			name = base + "V" + strconv.Itoa(i)
+		}
+		used[name] = true
+		return name
+	}
+
+	var (
+		gens                 []*genField
+		skipped, positional  []string
+		needTime, needFeishu bool
+	)
+	for i, f := range fields {
+		if strings.Contains(f.Name, ",") {
+			skipped = append(skipped, f.Name)
+			fmt.Fprintf(warn, "⚠️  字段 %s 的名称包含逗号，无法通过 bitable 标签映射，已跳过\n", strconv.Quote(f.Name))
+			continue
+		}
+		goName := goNames[f.Name]
+		if goName == "" {
+			goName = goIdentifier(f.Name)
+		}
+		if goName == "" {
+			// 按列序命名，字段 ID 是随机字符串，列序在增删其他字段前保持不变
+			goName = "Field" + strconv.Itoa(i+1)
+			positional = append(positional, f.Name)
+		}
+		g := &genField{field: f, goName: unique(goName)}
+		g.goType, g.readOnly = goFieldType(f.Type)
+		if f.Type == feishu.FieldTypeSingleSelect || f.Type == feishu.FieldTypeMultiSelect {
+			if f.Property != nil && len(f.Property.Options) > 0 {
+				g.optionType = unique(typeName + g.goName)
+				for _, option := range f.Property.Options {
+					g.options = append(g.options, feishu.StringValue(option.Name))
+				}
+				g.goType = g.optionType
+				if f.Type == feishu.FieldTypeMultiSelect {
+					g.goType = "[]" + g.optionType
+				}
+			}
+		}
+		needTime = needTime || strings.Contains(g.goType, "time.")
+		needFeishu = needFeishu || strings.Contains(g.goType, "feishu.")
+		gens = append(gens, g)
+	}
+	if len(positional) > 0 {
+		fmt.Fprintf(warn, "💡 %d 个字段（%s）按列序命名为 FieldN，可通过 -rename 字段名=Go字段名 指定名称\n",
+			len(positional), strings.Join(positional, "、"))
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by feishu gen structs; DO NOT EDIT.\n// 数据表: %s (%s)\n\n", tableName, tableID)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	if needTime || needFeishu {
+		b.WriteString("import (\n")
+		if needTime {
+			b.WriteString("\t\"time\"\n\n")
+		}
+		if needFeishu {
+			b.WriteString("\t\"feishu_bitable_demo/feishu\"\n")
+		}
+		b.WriteString(")\n\n")
+	}
+
+	fmt.Fprintf(&b, "// %s 数据表 %s 的记录\n", typeName, tableName)
+	fmt.Fprintf(&b, "type %s struct {\n", typeName)
+	b.WriteString("\tRecordID string `bitable:\",record_id\"`\n")
+	for _, g := range gens {
+		fmt.Fprintf(&b, "\n\t// %s\n", fieldComment(g))
+		opts := ""
+		if g.readOnly {
+			opts = ",readonly"
+		}
+		fmt.Fprintf(&b, "\t%s %s %s\n", g.goName, g.goType, structTag(g.field.Name+opts))
+	}
+	for _, name := range skipped {
+		fmt.Fprintf(&b, "\n\t// 字段 %s 的名称包含逗号，无法通过 bitable 标签映射\n", strconv.Quote(name))
+	}
+	b.WriteString("}\n")
+
+	for _, g := range gens {
+		if g.optionType == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "\n// %s 字段 %s 的选项\ntype %s string\n\nconst (\n", g.optionType, g.field.Name, g.optionType)
+		consts := make(map[string]bool, len(g.options))
+		for i, option := range g.options {
+			name := g.optionType + goIdentifier(option)
+			if name == g.optionType || consts[name] || used[name] {
+				name = g.optionType + "Option" + strconv.Itoa(i+1)
+			}
+			consts[name] = true
+			fmt.Fprintf(&b, "\t%s %s = %s\n", name, g.optionType, strconv.Quote(option))
+		}
+		b.WriteString(")\n")
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("格式化生成的代码失败: %w", err)
+	}
+	return src, nil
+}
+
+// goFieldType 返回字段类型对应的 Go 类型，以及字段是否只读
+func goFieldType(fieldType feishu.FieldType) (goType string, readOnly bool) {
+	readOnly = fieldType.ReadOnly()
+	switch fieldType {
+	case feishu.FieldTypeText, feishu.FieldTypeSingleSelect, feishu.FieldTypePhone, feishu.FieldTypeAutoNumber:
+		return "string", readOnly
+	case feishu.FieldTypeNumber: // 包括货币、进度、评分等展示形态
+		return "float64", readOnly
+	case feishu.FieldTypeMultiSelect:
+		return "[]string", readOnly
+	case feishu.FieldTypeDate, feishu.FieldTypeCreatedTime, feishu.FieldTypeModifiedTime:
+		return "time.Time", readOnly
+	case feishu.FieldTypeCheckbox:
+		return "bool", readOnly
+	case feishu.FieldTypeUser, feishu.FieldTypeCreatedUser, feishu.FieldTypeModifiedUser:
+		return "[]feishu.User", readOnly
+	case feishu.FieldTypeURL:
+		return "*feishu.Link", readOnly
+	case feishu.FieldTypeAttachment:
+		return "[]feishu.Attachment", readOnly
+	}
+	// 公式、查找引用、关联、地理位置、群组等字段保留原始值，可用 feishu.AsNumber 等函数解码
+	return "interface{}", readOnly
+}
+
+// fieldComment 生成结构体字段的注释，如 "状态：单选" 或 "库存金额：公式，只读"
+func fieldComment(g *genField) string {
+	typeName := g.field.Type.String()
+	if g.field.UIType != "" {
+		typeName += "/" + g.field.UIType
+	}
+	parts := []string{typeName}
+	if g.field.IsPrimary {
+		parts = append(parts, "索引列")
+	}
+	if g.readOnly {
+		parts = append(parts, "只读")
+	}
+	return g.field.Name + "：" + strings.Join(parts, "，")
+}
+
+// structTag 生成 bitable 标签，值中包含反引号时使用双引号字符串
+func structTag(value string) string {
+	tag := "bitable:" + strconv.Quote(value)
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
+}
+
+// goIdentifier 将只包含 ASCII 字符的名称转换为导出的 Go 标识符（如 unit price → UnitPrice），
+// 名称中包含中文等非 ASCII 字符或无法转换时返回空字符串
+func goIdentifier(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		switch {
+		case r >= utf8.RuneSelf:
+			return ""
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper {
+				r = unicode.ToUpper(r)
+				upper = false
+			}
+			b.WriteRune(r)
+		default:
+			upper = true
+		}
+	}
+	s := b.String()
+	if s == "" || !unicode.IsLetter(rune(s[0])) {
+		return ""
+	}
+	return s
+}
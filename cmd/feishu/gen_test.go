@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"feishu_bitable_demo/feishu"
+)
+
+var update = flag.Bool("update", false, "更新 testdata 中的 golden 文件")
+
+// genTestFields 覆盖中文字段名、重名、选项常量、只读字段和名称包含逗号的字段
+func genTestFields() []*feishu.Field {
+	return []*feishu.Field{
+		{ID: "fldPrimary", Name: "产品名称", Type: feishu.FieldTypeText, IsPrimary: true},
+		{ID: "fldName", Name: "name", Type: feishu.FieldTypeText},
+		{ID: "fldName2", Name: "Name", Type: feishu.FieldTypePhone},
+		{ID: "fldPrice", Name: "unit price", Type: feishu.FieldTypeNumber, UIType: "Currency"},
+		{ID: "fldStatus", Name: "status", Type: feishu.FieldTypeSingleSelect, Property: feishu.SelectOptions("在售", "on sale", "On-Sale", "下架")},
+		{ID: "fldTags", Name: "标签", Type: feishu.FieldTypeMultiSelect, Property: feishu.SelectOptions("new", "hot")},
+		{ID: "fldEmpty", Name: "分类", Type: feishu.FieldTypeSingleSelect},
+		{ID: "fldDate", Name: "上架日期", Type: feishu.FieldTypeDate},
+		{ID: "fldOnSale", Name: "是否上架", Type: feishu.FieldTypeCheckbox},
+		{ID: "fldOwner", Name: "负责人", Type: feishu.FieldTypeUser},
+		{ID: "fldSite", Name: "官网", Type: feishu.FieldTypeURL},
+		{ID: "fldFiles", Name: "附件", Type: feishu.FieldTypeAttachment},
+		{ID: "fldTotal", Name: "库存金额", Type: feishu.FieldTypeFormula},
+		{ID: "fldCreated", Name: "创建时间", Type: feishu.FieldTypeCreatedTime},
+		{ID: "fldQuote", Name: "`引用`", Type: feishu.FieldTypeText},
+		{ID: "fldComma", Name: "长,宽", Type: feishu.FieldTypeText},
+	}
+}
+
+func TestGenerateStructs(t *testing.T) {
+	var warnings bytes.Buffer
+	src, err := generateStructs(&warnings, "model", "Product", "产品列表", "tblProducts", genTestFields(), map[string]string{"产品名称": "Title"})
+	if err != nil {
+		t.Fatalf("generateStructs: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "structs.golden")
+	if *update {
+		if err := os.WriteFile(golden, src, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("读取 golden 文件失败（使用 -update 生成）: %v", err)
+	}
+	if !bytes.Equal(src, want) {
+		t.Errorf("generated code differs from %s (run go test -update to refresh):\n%s", golden, src)
+	}
+
+	formatted, err := format.Source(src)
+	if err != nil {
+		t.Fatalf("format.Source: %v", err)
+	}
+	if !bytes.Equal(formatted, src) {
+		t.Error("generated code is not gofmt-formatted")
+	}
+	typeCheck(t, src)
+
+	for _, want := range []string{`"长,宽" 的名称包含逗号`, "-rename"} {
+		if !strings.Contains(warnings.String(), want) {
+			t.Errorf("warnings = %q, want it to mention %q", warnings.String(), want)
+		}
+	}
+}
+
+func TestGenerateStructsWithoutImports(t *testing.T) {
+	fields := []*feishu.Field{{ID: "fld1", Name: "名称", Type: feishu.FieldTypeText, IsPrimary: true}}
+	src, err := generateStructs(io.Discard, "model", "Record", "数据表", "tbl1", fields, nil)
+	if err != nil {
+		t.Fatalf("generateStructs: %v", err)
+	}
+	if bytes.Contains(src, []byte("import")) {
+		t.Errorf("unexpected import:\n%s", src)
+	}
+	typeCheck(t, src)
+}
+
+// typeCheck 对生成的代码做类型检查，确认可以编译
+func typeCheck(t *testing.T, src []byte) {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "structs.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	// 使用 go list 输出的编译导出数据，避免从源码检查 SDK
+	out, err := exec.Command("go", "list", "-export", "-deps", "-f", "{{.ImportPath}}={{.Export}}", "time", "feishu_bitable_demo/feishu").Output()
+	if err != nil {
+		t.Fatalf("go list: %v", err)
+	}
+	exports := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		path, export, _ := strings.Cut(line, "=")
+		exports[path] = export
+	}
+	lookup := func(path string) (io.ReadCloser, error) {
+		return os.Open(exports[path])
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "gc", lookup)}
+	if _, err := conf.Check("model", fset, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("type check: %v\n%s", err, src)
+	}
+}
+
+func TestGoIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"unit price": "UnitPrice",
+		"on-sale":    "OnSale",
+		"name":       "Name",
+		"产品名称":       "",
+		"2nd":        "",
+		"fld_abc":    "FldAbc",
+		"":           "",
+	}
+	for name, want := range tests {
+		if got := goIdentifier(name); got != want {
+			t.Errorf("goIdentifier(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Command feishu 飞书多维表格与云文档命令行工具。
+//
+// 用法：
+//
+//	feishu <命令> [子命令] [参数]
+//
+// 所有子命令都支持 -config、-profile、-app-id 等通用参数，配置的加载顺序见 config 包。
+//
+// 退出码：
+//
+//	0  成功
+//	1  调用飞书接口失败等运行时错误
+//	2  命令或参数错误
+//	3  配置缺失或无效
+//	130 被 Ctrl+C 中断
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+// 退出码
+const (
+	exitOK          = 0
+	exitFailure     = 1
+	exitUsage       = 2
+	exitConfig      = 3
+	exitInterrupted = 130
+)
+
+// command 一个命令或命令组，命令组只包含子命令，不能直接执行
+type command struct {
+	name     string
+	args     string // 用法中的位置参数，如 "<record_id>"
+	summary  string
+	run      func(ctx context.Context, fs *flag.FlagSet, args []string) error
+	children []*command
+}
+
+// commands 所有顶层命令
+var commands = []*command{
+	recordsCommand,
+	tablesCommand,
+	fieldsCommand,
+	schemaCommand,
+	docsCommand,
+	exportCommand,
+	createCommand,
+	genCommand,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run 解析并执行命令，返回退出码
+func run(ctx context.Context, args []string, stderr io.Writer) int {
+	root := &command{name: "feishu", children: commands}
+	if len(args) > 0 && args[0] == "help" {
+		// feishu help records list 等价于 feishu records list -h
+		args = append(args[1:], "-h")
+	}
+
+	cmd, path, rest := root, []string{root.name}, args
+	for len(cmd.children) > 0 {
+		if len(rest) == 0 {
+			printGroupUsage(stderr, cmd, path)
+			return exitUsage
+		}
+		if rest[0] == "-h" || rest[0] == "-help" || rest[0] == "--help" {
+			printGroupUsage(stderr, cmd, path)
+			return exitOK
+		}
+		next := cmd.child(rest[0])
+		if next == nil {
+			fmt.Fprintf(stderr, "❌ 未知命令 %q\n\n", strings.Join(append(path, rest[0]), " "))
+			printGroupUsage(stderr, cmd, path)
+			return exitUsage
+		}
+		cmd, path, rest = next, append(path, next.name), rest[1:]
+	}
+
+	fs := flag.NewFlagSet(strings.Join(path, " "), flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { printCommandUsage(stderr, cmd, fs) }
+
+	err := cmd.run(ctx, fs, rest)
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errFlagParse):
+		// flag 包已经输出了错误和用法
+		return exitUsage
+	case errors.Is(err, context.Canceled) && ctx.Err() != nil:
+		fmt.Fprintln(stderr, "⚠️  已中断")
+		return exitInterrupted
+	}
+
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		fmt.Fprintf(stderr, "❌ %v\n", exitErr.err)
+		if exitErr.code == exitUsage {
+			fmt.Fprintln(stderr)
+			fs.Usage()
+		}
+		return exitErr.code
+	}
+	fmt.Fprintf(stderr, "❌ %v\n", err)
+	return exitFailure
+}
+
+// child 按名称查找子命令
+func (c *command) child(name string) *command {
+	for _, child := range c.children {
+		if child.name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// printGroupUsage 输出命令组的帮助
+func printGroupUsage(w io.Writer, cmd *command, path []string) {
+	name := strings.Join(path, " ")
+	if cmd.summary != "" {
+		fmt.Fprintf(w, "%s\n\n", cmd.summary)
+	}
+	fmt.Fprintf(w, "用法: %s <命令> [参数]\n\n命令:\n", name)
+	for _, child := range cmd.children {
+		fmt.Fprintf(w, "  %-10s %s\n", child.name, child.summary)
+	}
+	fmt.Fprintf(w, "\n运行 \"%s <命令> -h\" 查看命令的参数。\n", name)
+}
+
+// printCommandUsage 输出可执行命令的帮助
+func printCommandUsage(w io.Writer, cmd *command, fs *flag.FlagSet) {
+	fmt.Fprintf(w, "%s\n\n用法: %s [参数]", cmd.summary, fs.Name())
+	if cmd.args != "" {
+		fmt.Fprintf(w, " %s", cmd.args)
+	}
+	fmt.Fprint(w, "\n\n参数:\n")
+	fs.PrintDefaults()
+}
+
+// errFlagParse 参数解析失败，flag 包已输出错误信息
+var errFlagParse = errors.New("参数解析失败")
+
+// exitError 带退出码的错误
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// usageErrorf 返回参数错误，退出码为 2 并输出命令帮助
+func usageErrorf(format string, args ...interface{}) error {
+	return &exitError{code: exitUsage, err: fmt.Errorf(format, args...)}
+}
+
+// configError 返回配置错误，退出码为 3
+func configError(err error) error {
+	return &exitError{code: exitConfig, err: err}
+}
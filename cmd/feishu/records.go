@@ -0,0 +1,593 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"feishu_bitable_demo/config"
+	"feishu_bitable_demo/feishu"
+)
+
+var recordsCommand = &command{
+	name:    "records",
+	summary: "读写多维表格中的记录",
+	children: []*command{
+		{name: "list", summary: "列出数据表中的记录", run: runRecordsList},
+		{name: "query", summary: "按条件筛选、排序记录", run: runRecordsQuery},
+		{name: "get", args: "<record_id>", summary: "读取单条记录", run: runRecordsGet},
+		{name: "create", summary: "创建一条记录", run: runRecordsCreate},
+		{name: "update", args: "<record_id>...", summary: "更新一条或多条记录", run: runRecordsUpdate},
+		{name: "delete", args: "<record_id>...", summary: "删除一条或多条记录", run: runRecordsDelete},
+		{name: "delete-where", summary: "删除满足筛选条件的记录，可先备份", run: runRecordsDeleteWhere},
+		{name: "demo", summary: "在已有数据表上演示记录的增删改查", run: runRecordsDemo},
+	},
+}
+
+func runRecordsList(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	pageSize := fs.Int("page-size", 20, "每页记录数（最大 500）")
+	pageToken := fs.String("page-token", "", "上一页返回的分页标记")
+	all := fs.Bool("all", false, "自动翻页，列出所有记录")
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出")
+	s, err := parseFlags(ctx, fs, args, config.AppToken, config.TableID)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+
+	if *all {
+		items, err := s.client.ListAllRecordsContext(ctx, s.cfg.AppToken, s.cfg.TableID, *pageSize)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(items)
+		}
+		for _, item := range items {
+			printRecordLine(item)
+		}
+		fmt.Printf("共 %d 条记录\n", len(items))
+		return nil
+	}
+
+	items, next, hasMore, err := s.client.ListRecordsContext(ctx, s.cfg.AppToken, s.cfg.TableID, *pageSize, *pageToken)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(map[string]interface{}{
+			"items":      items,
+			"page_token": next,
+			"has_more":   hasMore,
+		})
+	}
+	for _, item := range items {
+		printRecordLine(item)
+	}
+	fmt.Printf("共 %d 条记录\n", len(items))
+	if hasMore {
+		fmt.Printf("还有更多记录，使用 -page-token %s 查询下一页\n", next)
+	}
+	return nil
+}
+
+func runRecordsQuery(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	var filter filterFlags
+	filter.bind(fs)
+	var sorts stringList
+	fs.Var(&sorts, "sort", "排序字段，可重复，字段名前加 - 表示倒序，如 -sort -创建时间")
+	selectFields := fs.String("select", "", "只返回这些字段，逗号分隔")
+	view := fs.String("view", "", "只查询该视图中的记录，可以填写 view_id 或带 view 参数的链接")
+	limit := fs.Int("limit", 0, "最多输出的记录数，0 表示全部")
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出")
+	s, err := parseFlags(ctx, fs, args, config.AppToken, config.TableID)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+	if *limit < 0 {
+		return usageErrorf("-limit 不能为负数")
+	}
+
+	viewID, err := resolveViewID(s, *view)
+	if err != nil {
+		return err
+	}
+	f, err := filter.filter()
+	if err != nil {
+		return err
+	}
+	q := feishu.NewQuery().View(viewID).Filter(f)
+	for _, field := range sorts {
+		desc := strings.HasPrefix(field, "-")
+		q.OrderBy(strings.TrimPrefix(field, "-"), desc)
+	}
+	if *selectFields != "" {
+		q.Select(strings.Split(*selectFields, ",")...)
+	}
+
+	pageSize := feishu.DefaultPageSize
+	if *limit > 0 {
+		pageSize = min(*limit, feishu.MaxPageSize)
+	}
+	var records []*feishu.Record
+	for record, err := range s.client.QueryRecordsContext(ctx, s.cfg.AppToken, s.cfg.TableID, q, pageSize) {
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+		if *limit > 0 && len(records) == *limit {
+			break
+		}
+	}
+
+	if *asJSON {
+		return printJSON(records)
+	}
+	for _, record := range records {
+		printRecordLine(record)
+	}
+	fmt.Printf("共 %d 条记录\n", len(records))
+	return nil
+}
+
+// filterFlags -where 和 -any 参数
+type filterFlags struct {
+	where stringList
+	any   bool
+}
+
+// bind 注册参数
+func (f *filterFlags) bind(fs *flag.FlagSet) {
+	fs.Var(&f.where, "where", "筛选条件 \"字段 运算符 [值]\"，可重复，如 -where '状态 = 在售' -where '库存数量<10'\n"+
+		"运算符: = != > >= < <= contains !contains empty !empty，也可以使用 is、isLess 等接口名称")
+	fs.BoolVar(&f.any, "any", false, "满足任意一个 -where 条件即可（默认需全部满足）")
+}
+
+// filter 将 -where 参数转换为筛选条件
+func (f *filterFlags) filter() (*feishu.Filter, error) {
+	conds := make([]feishu.FilterItem, 0, len(f.where))
+	for _, expr := range f.where {
+		cond, err := parseCondition(expr)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, cond)
+	}
+	if f.any {
+		return feishu.Or(conds...), nil
+	}
+	return feishu.And(conds...), nil
+}
+
+// operatorAliases 命令行筛选条件中运算符的简写
+var operatorAliases = map[string]feishu.Operator{
+	"=":         feishu.OpIs,
+	"!=":        feishu.OpIsNot,
+	">":         feishu.OpIsGreater,
+	">=":        feishu.OpIsGreaterEqual,
+	"<":         feishu.OpIsLess,
+	"<=":        feishu.OpIsLessEqual,
+	"contains":  feishu.OpContains,
+	"!contains": feishu.OpDoesNotContain,
+	"empty":     feishu.OpIsEmpty,
+	"!empty":    feishu.OpIsNotEmpty,
+}
+
+// symbolicOperators 可以与字段名、值连写的运算符，两个字符的在前以便优先匹配
+var symbolicOperators = []string{"!=", ">=", "<=", "=", ">", "<"}
+
+// parseCondition 解析 -where 参数，格式为 "字段 运算符 [值]"，值可以包含空格；
+// 使用 = != > >= < <= 时也可以省略空格，如 "库存数量<10"
+func parseCondition(expr string) (feishu.Condition, error) {
+	field, name, value, ok := splitCondition(strings.TrimSpace(expr))
+	if !ok {
+		return feishu.Condition{}, usageErrorf("无法解析筛选条件 %q，格式为 \"字段 运算符 [值]\"", expr)
+	}
+	op := feishu.Operator(name)
+	if alias, ok := operatorAliases[name]; ok {
+		op = alias
+	}
+
+	switch op {
+	case feishu.OpIsEmpty, feishu.OpIsNotEmpty:
+		if value != "" {
+			return feishu.Condition{}, usageErrorf("筛选条件 %q 中的运算符 %s 不需要值", expr, name)
+		}
+		return feishu.Cond(field, op), nil
+	case feishu.OpIs, feishu.OpIsNot, feishu.OpContains, feishu.OpDoesNotContain,
+		feishu.OpIsGreater, feishu.OpIsGreaterEqual, feishu.OpIsLess, feishu.OpIsLessEqual:
+		if value == "" {
+			return feishu.Condition{}, usageErrorf("筛选条件 %q 缺少值", expr)
+		}
+		return feishu.Cond(field, op, value), nil
+	}
+	return feishu.Condition{}, usageErrorf("筛选条件 %q 中的运算符 %s 不支持", expr, name)
+}
+
+// splitCondition 将筛选条件拆分为字段、运算符和值。
+// 先按空格拆分；第二部分不是运算符时，再按连写的符号运算符拆分。
+func splitCondition(expr string) (field, op, value string, ok bool) {
+	parts := strings.SplitN(expr, " ", 3)
+	if len(parts) < 2 || !isOperator(parts[1]) {
+		for i := 1; i < len(expr); i++ {
+			for _, sym := range symbolicOperators {
+				if strings.HasPrefix(expr[i:], sym) {
+					return strings.TrimSpace(expr[:i]), sym, strings.TrimSpace(expr[i+len(sym):]), true
+				}
+			}
+		}
+	}
+	switch len(parts) {
+	case 3:
+		return parts[0], parts[1], parts[2], true
+	case 2:
+		return parts[0], parts[1], "", true
+	}
+	return "", "", "", false
+}
+
+// isOperator 判断是否为运算符的简写或接口名称
+func isOperator(name string) bool {
+	if _, ok := operatorAliases[name]; ok {
+		return true
+	}
+	switch feishu.Operator(name) {
+	case feishu.OpIs, feishu.OpIsNot, feishu.OpContains, feishu.OpDoesNotContain, feishu.OpIsEmpty, feishu.OpIsNotEmpty,
+		feishu.OpIsGreater, feishu.OpIsGreaterEqual, feishu.OpIsLess, feishu.OpIsLessEqual:
+		return true
+	}
+	return false
+}
+
+// stringList 可重复指定的字符串参数
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ", ") }
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+func runRecordsGet(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出")
+	s, err := parseFlags(ctx, fs, args, config.AppToken, config.TableID)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 1); err != nil {
+		return err
+	}
+
+	record, err := s.client.GetRecordContext(ctx, s.cfg.AppToken, s.cfg.TableID, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(record)
+	}
+	fmt.Printf("record_id: %s\n", record.ID)
+	fmt.Printf("创建时间: %s（%s）\n", formatTime(record.CreatedTime), formatUser(record.CreatedBy))
+	fmt.Printf("修改时间: %s（%s）\n", formatTime(record.LastModifiedTime), formatUser(record.LastModifiedBy))
+	for _, name := range sortedKeys(record.Fields) {
+		fmt.Printf("%s: %s\n", name, formatValue(record.Fields[name]))
+	}
+	return nil
+}
+
+func runRecordsCreate(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	fieldsJSON := fs.String("fields", "", `字段值 JSON，如 '{"名称":"测试产品","数量":100}'`)
+	s, err := parseFlags(ctx, fs, args, config.AppToken, config.TableID)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+	fields, err := parseFields(*fieldsJSON)
+	if err != nil {
+		return err
+	}
+
+	record, err := s.client.CreateRecordContext(ctx, s.cfg.AppToken, s.cfg.TableID, fields)
+	if err != nil {
+		return err
+	}
+	fmt.Println(record.ID)
+	return nil
+}
+
+func runRecordsUpdate(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	fieldsJSON := fs.String("fields", "", `要更新的字段值 JSON，未包含的字段保持不变`)
+	since := fs.String("if-unmodified-since", "", "只更新在该时间之后未被修改过的记录，其余记录报告冲突并跳过\n"+
+		"（RFC3339 或 \"2006-01-02 15:04:05\" 格式的本地时间）")
+	s, err := parseFlags(ctx, fs, args, config.AppToken, config.TableID)
+	if err != nil {
+		return err
+	}
+	if err := minArgs(fs, 1); err != nil {
+		return err
+	}
+	fields, err := parseFields(*fieldsJSON)
+	if err != nil {
+		return err
+	}
+
+	if *since == "" {
+		updates := make([]feishu.UpdateRecordRequest, 0, fs.NArg())
+		for _, recordID := range fs.Args() {
+			updates = append(updates, feishu.UpdateRecordRequest{RecordID: recordID, Fields: fields})
+		}
+		result, err := s.client.BatchUpdateRecordsContext(ctx, s.cfg.AppToken, s.cfg.TableID, updates)
+		for _, recordID := range result.Succeeded() {
+			fmt.Printf("✅ 已更新记录 %s\n", recordID)
+		}
+		return err
+	}
+
+	t, err := parseTime(*since)
+	if err != nil {
+		return usageErrorf("-if-unmodified-since 格式错误: %v", err)
+	}
+	cond := feishu.UpdateCondition{UnmodifiedSince: t}
+	conflicts := 0
+	for _, recordID := range fs.Args() {
+		err := s.client.UpdateRecordIfUnmodifiedContext(ctx, s.cfg.AppToken, s.cfg.TableID, recordID, cond, fields)
+		switch {
+		case errors.Is(err, feishu.ErrConflict):
+			conflicts++
+			fmt.Printf("⚠️  %v，已跳过\n", err)
+		case err != nil:
+			return err
+		default:
+			fmt.Printf("✅ 已更新记录 %s\n", recordID)
+		}
+	}
+	if conflicts > 0 {
+		return fmt.Errorf("%d 条记录因冲突未更新", conflicts)
+	}
+	return nil
+}
+
+// parseTime 解析 RFC3339 或 "2006-01-02 15:04:05" 格式的本地时间
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02 15:04:05", s, time.Local)
+}
+
+func runRecordsDelete(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	s, err := parseFlags(ctx, fs, args, config.AppToken, config.TableID)
+	if err != nil {
+		return err
+	}
+	if err := minArgs(fs, 1); err != nil {
+		return err
+	}
+
+	if err := s.client.BatchDeleteRecordsContext(ctx, s.cfg.AppToken, s.cfg.TableID, fs.Args()); err != nil {
+		return err
+	}
+	fmt.Printf("✅ 已删除 %d 条记录\n", fs.NArg())
+	return nil
+}
+
+func runRecordsDeleteWhere(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	var filter filterFlags
+	filter.bind(fs)
+	all := fs.Bool("all", false, "未指定 -where 时删除数据表中的所有记录")
+	backup := fs.String("backup", "", "删除前将匹配的记录备份到该 NDJSON 文件（每行一条记录）")
+	yes := fs.Bool("yes", false, "跳过删除确认")
+	s, err := parseFlags(ctx, fs, args, config.AppToken, config.TableID)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+	if len(filter.where) == 0 && !*all {
+		return usageErrorf("需要指定 -where 筛选条件，或使用 -all 删除所有记录")
+	}
+	f, err := filter.filter()
+	if err != nil {
+		return err
+	}
+
+	var opts feishu.DeleteWhereOptions
+	declined := false
+	if !*yes {
+		opts.Confirm = func(records []*feishu.Record) bool {
+			declined = !confirmDelete(records)
+			return !declined
+		}
+	}
+	if *backup != "" {
+		file, err := os.OpenFile(*backup, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			return fmt.Errorf("创建备份文件失败: %w", err)
+		}
+		defer removeIfEmpty(file)
+		opts.Backup = file
+	}
+
+	deleted, err := s.client.DeleteWhereContext(ctx, s.cfg.AppToken, s.cfg.TableID, feishu.NewQuery().Filter(f), opts)
+	switch {
+	case declined:
+		return errors.New("已取消删除")
+	case deleted == 0 && err == nil:
+		fmt.Println("没有满足条件的记录")
+		return nil
+	case deleted > 0:
+		fmt.Printf("✅ 已删除 %d 条记录\n", deleted)
+		if *backup != "" {
+			fmt.Printf("   备份文件: %s\n", *backup)
+		}
+	}
+	return err
+}
+
+// confirmDelete 列出将要删除的记录数并等待用户在标准输入中输入 yes 确认
+func confirmDelete(records []*feishu.Record) bool {
+	fmt.Fprintf(os.Stderr, "⚠️  将删除 %d 条记录，输入 yes 确认: ", len(records))
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.TrimSpace(answer) == "yes"
+}
+
+// demoProduct records demo 使用的记录结构
+type demoProduct struct {
+	ID          string    `bitable:",record_id"`
+	Name        string    `bitable:"名称"`
+	Quantity    float64   `bitable:"数量"`
+	Price       float64   `bitable:"价格"`
+	Description string    `bitable:"描述"`
+	CreatedAt   time.Time `bitable:"创建时间,omitempty"`
+	OnSale      bool      `bitable:"是否上架"`
+}
+
+// runRecordsDemo 在配置的数据表上依次创建、读取、更新、查询、批量写入并清理测试记录。
+// 数据表需要包含 名称、数量、价格、描述、创建时间、是否上架 这些字段。
+func runRecordsDemo(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	s, err := parseFlags(ctx, fs, args, config.AppToken, config.TableID)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+	products := feishu.NewTable[demoProduct](s.client, s.cfg.AppToken, s.cfg.TableID)
+
+	printBanner("🚀 飞书多维表格操作验证程序")
+
+	fmt.Println("📝 步骤 1: 创建单个记录")
+	product, err := products.CreateContext(ctx, demoProduct{
+		Name:        "测试产品",
+		Quantity:    100,
+		Price:       299.99,
+		Description: "这是一个测试产品，用于验证飞书多维表格的创建功能",
+		CreatedAt:   time.Now(),
+		OnSale:      true,
+	})
+	if err != nil {
+		return err
+	}
+	recordID := product.ID
+	fmt.Printf("✅ 成功创建记录，ID: %s\n\n", recordID)
+
+	fmt.Println("📝 步骤 2: 读取记录")
+	product, err = products.GetContext(ctx, recordID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("   记录内容: %+v\n", *product)
+	fmt.Printf("✅ 成功读取记录\n\n")
+
+	fmt.Println("📝 步骤 3: 更新记录")
+	product.Quantity = 200
+	product.Price = 399.99
+	product.Description = "更新后的产品描述"
+	product.OnSale = false
+	if err := products.UpdateContext(ctx, *product); err != nil {
+		return err
+	}
+	fmt.Printf("✅ 成功更新记录\n\n")
+
+	fmt.Println("📝 步骤 4: 查询记录")
+	count := 0
+	for p, err := range products.QueryContext(ctx, feishu.NewQuery().Where(feishu.IsGreaterEqual("数量", 100)), 0) {
+		if err != nil {
+			return err
+		}
+		if count < 3 { // 只显示前 3 条
+			fmt.Printf("   [%d] %s %s 数量=%v\n", count+1, p.ID, p.Name, p.Quantity)
+		}
+		count++
+	}
+	fmt.Printf("   数量不少于 100 的记录共 %d 条\n", count)
+	fmt.Printf("✅ 成功查询记录\n\n")
+
+	fmt.Println("📝 步骤 5: 按名称批量写入记录（Upsert）")
+	var batch []demoProduct
+	for i, name := range []string{"A", "B", "C"} {
+		batch = append(batch, demoProduct{
+			Name:        "批量产品 " + name,
+			Quantity:    float64(50 + i*25),
+			Price:       199.99 + float64(i)*50,
+			Description: "批量创建的测试产品 " + name,
+			CreatedAt:   time.Now(),
+			OnSale:      i%2 == 0,
+		})
+	}
+	result, err := products.UpsertContext(ctx, []string{"名称"}, batch)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ 新建 %d 条，更新 %d 条，未变化 %d 条\n\n", result.Created, result.Updated, result.Unchanged)
+
+	fmt.Println("📝 步骤 6: 再次 Upsert，更新数量和描述")
+	for i := range batch {
+		batch[i].Quantity = float64(100 + i*10)
+		batch[i].Description = fmt.Sprintf("批量更新的产品 %d", i+1)
+	}
+	result, err = products.UpsertContext(ctx, []string{"名称"}, batch)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ 新建 %d 条，更新 %d 条，未变化 %d 条\n\n", result.Created, result.Updated, result.Unchanged)
+
+	fmt.Println("📝 步骤 7: 删除测试记录")
+	for _, id := range append([]string{recordID}, result.RecordIDs...) {
+		if err := products.DeleteContext(ctx, id); err != nil {
+			fmt.Printf("⚠️  删除记录 %s 失败: %v\n", id, err)
+		}
+	}
+	fmt.Printf("✅ 成功清理测试记录\n\n")
+
+	printBanner("🎉 所有测试通过！飞书多维表格操作功能正常")
+	return nil
+}
+
+// printRecordLine 以一行输出记录 ID 和字段
+func printRecordLine(record *feishu.Record) {
+	fmt.Printf("%s\t%s\n", record.ID, formatFields(record.Fields))
+}
+
+// formatTime 格式化记录的创建、修改时间，零值输出 -
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// formatUser 格式化记录的创建人、修改人
+func formatUser(u *feishu.User) string {
+	switch {
+	case u == nil:
+		return "-"
+	case u.Name != "":
+		return u.Name
+	}
+	return u.ID
+}
+
+// removeIfEmpty 关闭文件，未写入任何内容时删除文件
+func removeIfEmpty(file *os.File) {
+	info, err := file.Stat()
+	file.Close()
+	if err == nil && info.Size() == 0 {
+		os.Remove(file.Name())
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"feishu_bitable_demo/feishu"
+)
+
+func TestRecordsQueryView(t *testing.T) {
+	client, appToken, tableID := setupCLI(t)
+	views, err := client.ListViews(appToken, tableID)
+	if err != nil {
+		t.Fatalf("ListViews: %v", err)
+	}
+	viewID := views[0].ID
+	base := "https://xxx.feishu.cn/base/" + appToken
+
+	tests := []struct {
+		name string
+		view string
+		want int
+	}{
+		{"view_id", viewID, exitOK},
+		{"多维表格链接", base + "?table=" + tableID + "&view=" + viewID, exitOK},
+		{"不带 table 参数的链接", base + "?view=" + viewID, exitOK},
+		{"知识库链接", "https://xxx.feishu.cn/wiki/wikcnAbc?table=" + tableID + "&view=" + viewID, exitOK},
+		{"链接中的视图不存在", base + "?table=" + tableID + "&view=vewMissing", exitFailure},
+		{"链接中没有 view 参数", base + "?table=" + tableID, exitUsage},
+		{"链接中的数据表不一致", base + "?table=tblOther&view=" + viewID, exitUsage},
+		{"无法解析的链接", "https://xxx.feishu.cn/sheets/shtcnAbc", exitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runCLI(t, tt.want, "records", "query", "-view", tt.view)
+		})
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		expr string
+		want feishu.Condition
+	}{
+		{"状态 = 在售", feishu.Is("状态", "在售")},
+		{"状态=在售", feishu.Is("状态", "在售")},
+		{"  状态 != 下架  ", feishu.IsNot("状态", "下架")},
+		{"库存数量<10", feishu.IsLess("库存数量", "10")},
+		{"库存数量<=10", feishu.IsLessEqual("库存数量", "10")},
+		{"库存数量 >= 10", feishu.IsGreaterEqual("库存数量", "10")},
+		{"库存数量>10", feishu.IsGreater("库存数量", "10")},
+		{"库存 数量<10", feishu.IsLess("库存 数量", "10")},
+		{"描述 contains 新品 上市", feishu.Contains("描述", "新品 上市")},
+		{"描述 !contains a=b", feishu.DoesNotContain("描述", "a=b")},
+		{"描述 empty", feishu.IsEmpty("描述")},
+		{"描述 !empty", feishu.IsNotEmpty("描述")},
+		{"库存数量 isGreaterEqual 5", feishu.IsGreaterEqual("库存数量", "5")},
+		{"备注 isNotEmpty", feishu.IsNotEmpty("备注")},
+	}
+	for _, tt := range tests {
+		got, err := parseCondition(tt.expr)
+		if err != nil {
+			t.Errorf("parseCondition(%q): %v", tt.expr, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseCondition(%q) = %+v, want %+v", tt.expr, got, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"", "状态", "=在售", "状态 =", "状态=", "状态 like 在售", "描述 empty 1", "描述 isEmpty x"} {
+		var exitErr *exitError
+		if got, err := parseCondition(expr); !errors.As(err, &exitErr) || exitErr.code != exitUsage {
+			t.Errorf("parseCondition(%q) = %+v, %v; want a usage error", expr, got, err)
+		}
+	}
+}
+
+func TestRecordsQueryWhere(t *testing.T) {
+	setupCLI(t)
+	runCLI(t, exitOK, "records", "query", "-where", "名称=苹果", "-where", "状态 !empty", "-any")
+	runCLI(t, exitUsage, "records", "query", "-where", "名称 like 苹果")
+}
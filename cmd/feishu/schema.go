@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"feishu_bitable_demo/config"
+	"feishu_bitable_demo/feishu"
+	"feishu_bitable_demo/feishu/fakeserver"
+	"feishu_bitable_demo/schema"
+)
+
+var schemaCommand = &command{
+	name:    "schema",
+	summary: "以 YAML 文件声明多维表格的数据表、字段和视图",
+	children: []*command{
+		{name: "plan", summary: "对比 schema 文件与多维表格，列出需要的变更", run: runSchemaPlan},
+		{name: "apply", summary: "按 schema 文件新增、修改、删除字段、视图和数据表", run: runSchemaApply},
+	},
+}
+
+// schemaFlags plan、apply 命令共用的参数
+type schemaFlags struct {
+	file string
+	fake bool
+	opts config.Options
+}
+
+func (f *schemaFlags) bind(fs *flag.FlagSet) {
+	fs.StringVar(&f.file, "f", "schema.yaml", "schema 文件路径")
+	fs.BoolVar(&f.fake, "fake", false, "不连接飞书，对比内存中的模拟服务和示例产品表，用于测试 schema 文件")
+	f.opts.BindFlags(fs)
+}
+
+// load 读取 schema 文件并连接多维表格，返回的 cleanup 用于关闭 -fake 模式的模拟服务
+func (f *schemaFlags) load(ctx context.Context) (s *schema.Schema, client *feishu.MultiTableClient, appToken string, cleanup func(), err error) {
+	s, err = schema.Load(f.file)
+	if err != nil {
+		return nil, nil, "", nil, err
+	}
+	if f.fake {
+		srv := fakeserver.New()
+		client = srv.NewClient()
+		appToken, _, err = client.CreateAppAndTableContext(ctx, "示例多维表格", "", "产品列表", productTableFields())
+		if err != nil {
+			srv.Close()
+			return nil, nil, "", nil, err
+		}
+		return s, client, appToken, srv.Close, nil
+	}
+	sess, err := newSession(ctx, f.opts, config.AppToken)
+	if err != nil {
+		return nil, nil, "", nil, err
+	}
+	return s, sess.client, sess.cfg.AppToken, func() {}, nil
+}
+
+func runSchemaPlan(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	var flags schemaFlags
+	flags.bind(fs)
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+	s, client, appToken, cleanup, err := flags.load(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	plan, err := schema.DiffContext(ctx, client, appToken, s)
+	if err != nil {
+		return err
+	}
+	fmt.Print(plan)
+	if len(plan.Destructive()) > 0 {
+		fmt.Println("破坏性变更需要使用 feishu schema apply -allow-destructive 执行")
+	}
+	return nil
+}
+
+func runSchemaApply(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	var flags schemaFlags
+	flags.bind(fs)
+	allowDestructive := fs.Bool("allow-destructive", false, "执行删除字段、视图、数据表以及修改字段类型、删除选项等破坏性变更")
+	yes := fs.Bool("yes", false, "跳过执行确认")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+	s, client, appToken, cleanup, err := flags.load(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	plan, err := schema.DiffContext(ctx, client, appToken, s)
+	if err != nil {
+		return err
+	}
+	fmt.Print(plan)
+	if len(plan.Changes) == 0 {
+		return nil
+	}
+	n := len(plan.Changes)
+	if !*allowDestructive {
+		n -= len(plan.Destructive())
+	}
+	if !*yes && n > 0 && !confirmApply(n) {
+		return errors.New("已取消执行")
+	}
+
+	result, err := schema.ApplyContext(ctx, client, plan, schema.ApplyOptions{
+		AllowDestructive: *allowDestructive,
+		OnChange: func(c *schema.Change) {
+			fmt.Printf("✅ %s\n", c)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n已执行 %d 项变更\n", len(result.Applied))
+	if len(result.Skipped) > 0 {
+		fmt.Printf("⚠️  跳过 %d 项破坏性变更，确认后使用 -allow-destructive 执行：\n", len(result.Skipped))
+		for _, c := range result.Skipped {
+			fmt.Printf("   %s\n", c)
+		}
+	}
+	return nil
+}
+
+// confirmApply 等待用户在标准输入中输入 yes 确认执行 n 项变更
+func confirmApply(n int) bool {
+	fmt.Fprintf(os.Stderr, "\n⚠️  将执行 %d 项变更，输入 yes 确认: ", n)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.TrimSpace(answer) == "yes"
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"feishu_bitable_demo/config"
+	"feishu_bitable_demo/feishu"
+)
+
+// session 命令执行所需的配置和客户端
+type session struct {
+	cfg    *config.Feishu
+	client *feishu.MultiTableClient
+}
+
+// parseFlags 绑定通用参数并解析命令行，加载配置并校验 required 中的配置项后创建客户端。
+// 命令专属的参数需要在调用前注册到 fs 上。
+func parseFlags(ctx context.Context, fs *flag.FlagSet, args []string, required ...config.Field) (*session, error) {
+	var opts config.Options
+	opts.BindFlags(fs)
+	if err := parseArgs(fs, args); err != nil {
+		return nil, err
+	}
+	return newSession(ctx, opts, required...)
+}
+
+// parseArgs 解析命令行，解析失败时返回 errFlagParse（flag 包已输出错误信息）
+func parseArgs(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errFlagParse
+	}
+	return nil
+}
+
+// newSession 按通用参数加载配置，校验 required 中的配置项后创建客户端
+func newSession(ctx context.Context, opts config.Options, required ...config.Field) (*session, error) {
+	cfg, err := config.Load(opts)
+	if err != nil {
+		return nil, configError(fmt.Errorf("读取配置失败: %w", err))
+	}
+	if err := cfg.Validate(append([]config.Field{config.AppID, config.AppSecret}, required...)...); err != nil {
+		return nil, configError(fmt.Errorf("配置不完整:\n%w", err))
+	}
+
+	client := cfg.NewClient()
+	if err := cfg.Resolve(ctx, client); err != nil {
+		return nil, configError(err)
+	}
+	return &session{cfg: cfg, client: client}, nil
+}
+
+// resolveDocumentID 解析位置参数中的云文档，支持 document_id、云文档链接和知识库链接
+func resolveDocumentID(ctx context.Context, s *session, arg string) (string, error) {
+	if !feishu.IsURL(arg) {
+		return arg, nil
+	}
+	loc, err := s.client.ResolveURLContext(ctx, arg)
+	if err != nil {
+		return "", usageErrorf("无法解析文档链接: %v", err)
+	}
+	if loc.DocumentID == "" {
+		return "", usageErrorf("链接指向的不是云文档: %s", arg)
+	}
+	return loc.DocumentID, nil
+}
+
+// resolveViewID 解析 -view 参数，支持 view_id 和带 view 参数的多维表格或知识库链接
+func resolveViewID(s *session, arg string) (string, error) {
+	if !feishu.IsURL(arg) {
+		return arg, nil
+	}
+	loc, err := feishu.ParseURL(arg)
+	if err != nil {
+		return "", usageErrorf("无法解析视图链接: %v", err)
+	}
+	if loc.ViewID == "" {
+		return "", usageErrorf("链接中没有 view 参数，请在多维表格中切换到该视图后再复制链接: %s", arg)
+	}
+	if loc.TableID != "" && loc.TableID != s.cfg.TableID {
+		return "", usageErrorf("视图链接中的数据表 %s 与当前数据表 %s 不一致", loc.TableID, s.cfg.TableID)
+	}
+	return loc.ViewID, nil
+}
+
+// exactArgs 校验位置参数的个数
+func exactArgs(fs *flag.FlagSet, n int) error {
+	if fs.NArg() != n {
+		return usageErrorf("需要 %d 个参数，实际为 %d 个", n, fs.NArg())
+	}
+	return nil
+}
+
+// minArgs 校验位置参数的最少个数
+func minArgs(fs *flag.FlagSet, n int) error {
+	if fs.NArg() < n {
+		return usageErrorf("至少需要 %d 个参数，实际为 %d 个", n, fs.NArg())
+	}
+	return nil
+}
+
+// parseFields 解析 -fields 参数中的 JSON 对象
+func parseFields(s string) (map[string]interface{}, error) {
+	if s == "" {
+		return nil, usageErrorf("缺少 -fields 参数")
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &fields); err != nil {
+		return nil, usageErrorf("-fields 不是合法的 JSON 对象: %v", err)
+	}
+	return fields, nil
+}
+
+// printJSON 以缩进格式输出 JSON
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// printBanner 输出演示流程的标题
+func printBanner(title string) {
+	fmt.Println("=================================================")
+	fmt.Println(title)
+	fmt.Println("=================================================")
+	fmt.Println()
+}
+
+// formatFields 将记录字段格式化为一行文本，字段按名称排序
+func formatFields(fields map[string]interface{}) string {
+	names := sortedKeys(fields)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, name+": "+formatValue(fields[name]))
+	}
+	return strings.Join(parts, "；")
+}
+
+// sortedKeys 返回按名称排序的字段名
+func sortedKeys(fields map[string]interface{}) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatValue 格式化单个字段值，复杂类型输出为 JSON
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	case []interface{}, map[string]interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"feishu_bitable_demo/config"
+	"feishu_bitable_demo/feishu"
+)
+
+var tablesCommand = &command{
+	name:    "tables",
+	summary: "管理多维表格中的数据表",
+	children: []*command{
+		{name: "list", summary: "列出多维表格中的所有数据表", run: runTablesList},
+	},
+}
+
+func runTablesList(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出")
+	s, err := parseFlags(ctx, fs, args, config.AppToken)
+	if err != nil {
+		return err
+	}
+	if err := exactArgs(fs, 0); err != nil {
+		return err
+	}
+
+	tables, err := s.client.ListTablesContext(ctx, s.cfg.AppToken)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(tables)
+	}
+	for _, table := range tables {
+		fmt.Printf("%s\t%s\n", feishu.StringValue(table.TableId), feishu.StringValue(table.Name))
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// Package config 统一加载飞书应用配置。
+//
+// 配置来源按优先级从低到高依次为：
+//  1. config.yaml 中的 feishu 段（默认配置）
+//  2. config.yaml 中 profiles 下选中的 profile（未填写的字段沿用默认配置）
+//  3. FEISHU_* 环境变量
+//  4. 命令行参数
+//
+// app_token、table_id 和 folder_token 可以直接填写从浏览器复制的飞书链接，
+// 加载时会解析出对应的值；知识库中的多维表格需要再调用 Feishu.Resolve。
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"feishu_bitable_demo/feishu"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPath 默认配置文件路径
+const DefaultPath = "config.yaml"
+
+// Feishu 单个飞书应用（租户）的配置
+type Feishu struct {
+	AppID       string `yaml:"app_id"`
+	AppSecret   string `yaml:"app_secret"`
+	AppToken    string `yaml:"app_token"`    // 多维表格 app_token，也可以填写多维表格或知识库的网页链接
+	TableID     string `yaml:"table_id"`     // 多维表格 table_id，也可以填写带 table 参数的网页链接
+	FolderToken string `yaml:"folder_token"` // 云空间文件夹 token 或文件夹链接（可选）
+	BaseURL     string `yaml:"base_url"`     // 开放平台地址（可选），国际版为 https://open.larksuite.com
+
+	RateLimits map[feishu.Endpoint]feishu.RateLimit `yaml:"rate_limits"` // 接口限流配置（可选）
+}
+
+// File config.yaml 的文件结构
+type File struct {
+	Feishu         Feishu            `yaml:"feishu"`          // 默认配置
+	DefaultProfile string            `yaml:"default_profile"` // 未指定 profile 时使用
+	Profiles       map[string]Feishu `yaml:"profiles"`        // 多个应用 / 租户的配置
+}
+
+// Options 加载配置的选项
+type Options struct {
+	Path      string // 配置文件路径，为空时使用 DefaultPath，且文件不存在时不报错
+	Profile   string // 使用的 profile，为空时依次取 FEISHU_PROFILE 环境变量和 default_profile
+	Overrides Feishu // 命令行参数等更高优先级的值，非空字段会覆盖其他来源
+}
+
+// BindFlags 将通用的命令行参数绑定到 Options
+func (o *Options) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Path, "config", "", "配置文件路径（默认 "+DefaultPath+"）")
+	fs.StringVar(&o.Profile, "profile", "", "使用 config.yaml 中 profiles 下的指定配置")
+	fs.StringVar(&o.Overrides.AppID, "app-id", "", "飞书应用 app_id")
+	fs.StringVar(&o.Overrides.AppSecret, "app-secret", "", "飞书应用 app_secret")
+	fs.StringVar(&o.Overrides.AppToken, "app-token", "", "多维表格 app_token 或链接")
+	fs.StringVar(&o.Overrides.TableID, "table-id", "", "多维表格 table_id 或带 table 参数的链接")
+	fs.StringVar(&o.Overrides.FolderToken, "folder-token", "", "云空间文件夹 token 或链接")
+	fs.StringVar(&o.Overrides.BaseURL, "base-url", "", "开放平台地址")
+}
+
+// Load 按优先级合并各来源，返回最终配置
+func Load(opts Options) (*Feishu, error) {
+	file, err := readFile(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Feishu
+	apply := func(source string, layer Feishu) error {
+		if err := layer.expandURLs(); err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+		cfg.merge(layer)
+		return nil
+	}
+
+	if err := apply("配置文件 feishu 段", file.Feishu); err != nil {
+		return nil, err
+	}
+
+	profile := opts.Profile
+	if profile == "" {
+		profile = os.Getenv("FEISHU_PROFILE")
+	}
+	if profile == "" {
+		profile = file.DefaultProfile
+	}
+	if profile != "" {
+		p, ok := file.Profiles[profile]
+		if !ok {
+			return nil, fmt.Errorf("配置文件中不存在 profile %q", profile)
+		}
+		if err := apply("profile "+profile, p); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := apply("环境变量", fromEnv()); err != nil {
+		return nil, err
+	}
+	if err := apply("命令行参数", opts.Overrides); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// readFile 读取配置文件，使用默认路径且文件不存在时返回空配置（仅依赖环境变量和命令行参数）
+func readFile(path string) (*File, error) {
+	explicit := path != ""
+	if !explicit {
+		path = DefaultPath
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return &File{}, nil
+		}
+		return nil, err
+	}
+
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %w", path, err)
+	}
+	return &file, nil
+}
+
+// fromEnv 读取 FEISHU_* 环境变量
+func fromEnv() Feishu {
+	return Feishu{
+		AppID:       os.Getenv("FEISHU_APP_ID"),
+		AppSecret:   os.Getenv("FEISHU_APP_SECRET"),
+		AppToken:    os.Getenv("FEISHU_APP_TOKEN"),
+		TableID:     os.Getenv("FEISHU_TABLE_ID"),
+		FolderToken: os.Getenv("FEISHU_FOLDER_TOKEN"),
+		BaseURL:     os.Getenv("FEISHU_BASE_URL"),
+	}
+}
+
+// merge 用 other 中的非空字段覆盖当前配置
+func (c *Feishu) merge(other Feishu) {
+	set := func(dst *string, src string) {
+		if src != "" {
+			*dst = src
+		}
+	}
+	set(&c.AppID, other.AppID)
+	set(&c.AppSecret, other.AppSecret)
+	set(&c.AppToken, other.AppToken)
+	set(&c.TableID, other.TableID)
+	set(&c.FolderToken, other.FolderToken)
+	set(&c.BaseURL, other.BaseURL)
+
+	if len(other.RateLimits) > 0 {
+		limits := make(map[feishu.Endpoint]feishu.RateLimit, len(c.RateLimits)+len(other.RateLimits))
+		for k, v := range c.RateLimits {
+			limits[k] = v
+		}
+		for k, v := range other.RateLimits {
+			limits[k] = v
+		}
+		c.RateLimits = limits
+	}
+}
+
+// NewClient 根据配置创建客户端，opts 可追加或覆盖客户端选项
+func (c *Feishu) NewClient(opts ...feishu.Option) *feishu.MultiTableClient {
+	options := []feishu.Option{feishu.WithRateLimits(c.RateLimits)}
+	if c.BaseURL != "" {
+		options = append(options, feishu.WithBaseURL(c.BaseURL))
+	}
+	return feishu.NewMultiTableClient(c.AppID, c.AppSecret, append(options, opts...)...)
+}
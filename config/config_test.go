@@ -0,0 +1,264 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"feishu_bitable_demo/feishu"
+)
+
+const testConfig = `
+feishu:
+  app_id: file_app
+  app_secret: file_secret
+  app_token: file_token
+  table_id: file_table
+  rate_limits:
+    record_write: {qps: 5, burst: 5}
+default_profile: staging
+profiles:
+  staging:
+    app_id: staging_app
+    app_token: staging_token
+  prod:
+    app_id: prod_app
+    app_secret: prod_secret
+    rate_limits:
+      record_write: {qps: 10, burst: 20}
+`
+
+// writeConfig 将配置写入临时文件并返回路径
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// clearEnv 清空会影响 Load 的环境变量
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{"FEISHU_PROFILE", "FEISHU_APP_ID", "FEISHU_APP_SECRET", "FEISHU_APP_TOKEN",
+		"FEISHU_TABLE_ID", "FEISHU_FOLDER_TOKEN", "FEISHU_BASE_URL"} {
+		t.Setenv(name, "")
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		env     map[string]string
+		opts    Options
+		want    Feishu
+	}{
+		{
+			name:    "只有默认配置",
+			content: "feishu:\n  app_id: file_app\n  app_secret: file_secret\n",
+			want:    Feishu{AppID: "file_app", AppSecret: "file_secret"},
+		},
+		{
+			name:    "default_profile 覆盖默认配置，未填写的字段沿用默认配置",
+			content: testConfig,
+			want:    Feishu{AppID: "staging_app", AppSecret: "file_secret", AppToken: "staging_token", TableID: "file_table"},
+		},
+		{
+			name:    "环境变量选择 profile",
+			content: testConfig,
+			env:     map[string]string{"FEISHU_PROFILE": "prod"},
+			want:    Feishu{AppID: "prod_app", AppSecret: "prod_secret", AppToken: "file_token", TableID: "file_table"},
+		},
+		{
+			name:    "参数选择 profile 优先于环境变量",
+			content: testConfig,
+			env:     map[string]string{"FEISHU_PROFILE": "staging"},
+			opts:    Options{Profile: "prod"},
+			want:    Feishu{AppID: "prod_app", AppSecret: "prod_secret", AppToken: "file_token", TableID: "file_table"},
+		},
+		{
+			name:    "环境变量覆盖 profile",
+			content: testConfig,
+			env:     map[string]string{"FEISHU_APP_ID": "env_app", "FEISHU_TABLE_ID": "env_table"},
+			want:    Feishu{AppID: "env_app", AppSecret: "file_secret", AppToken: "staging_token", TableID: "env_table"},
+		},
+		{
+			name:    "命令行参数覆盖环境变量",
+			content: testConfig,
+			env:     map[string]string{"FEISHU_APP_ID": "env_app", "FEISHU_BASE_URL": "https://env.example.com"},
+			opts:    Options{Overrides: Feishu{AppID: "flag_app", AppToken: "flag_token"}},
+			want:    Feishu{AppID: "flag_app", AppSecret: "file_secret", AppToken: "flag_token", TableID: "file_table", BaseURL: "https://env.example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			tt.opts.Path = writeConfig(t, tt.content)
+
+			cfg, err := Load(tt.opts)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			cfg.RateLimits = nil // 限流配置的合并见 TestLoadMergesRateLimits
+			if !reflect.DeepEqual(*cfg, tt.want) {
+				t.Errorf("Load = %+v, want %+v", *cfg, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadMergesRateLimits(t *testing.T) {
+	clearEnv(t)
+	cfg, err := Load(Options{Path: writeConfig(t, testConfig), Profile: "prod"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := cfg.RateLimits[feishu.EndpointRecordWrite]; got.QPS != 10 || got.Burst != 20 {
+		t.Errorf("RateLimits[record_write] = %+v, want the profile's limit", got)
+	}
+}
+
+func TestLoadFlags(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("FEISHU_APP_ID", "env_app")
+	var opts Options
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	opts.BindFlags(fs)
+	if err := fs.Parse([]string{"-config", writeConfig(t, testConfig), "-profile", "prod", "-app-id", "flag_app"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(opts)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.AppID != "flag_app" || cfg.AppSecret != "prod_secret" {
+		t.Errorf("Load = %+v, want app_id from the flag and app_secret from profile prod", *cfg)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		env     map[string]string
+		opts    Options
+		want    string
+	}{
+		{name: "参数指定的 profile 不存在", content: testConfig, opts: Options{Profile: "dev"}, want: `profile "dev"`},
+		{name: "环境变量指定的 profile 不存在", content: testConfig, env: map[string]string{"FEISHU_PROFILE": "dev"}, want: `profile "dev"`},
+		{name: "default_profile 不存在", content: "default_profile: dev\n", want: `profile "dev"`},
+		{name: "YAML 格式错误", content: "feishu: [", want: "解析"},
+		{name: "链接无法解析", content: "feishu:\n  table_id: https://example.feishu.cn/base/bascnXXX\n", want: "table_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			tt.opts.Path = writeConfig(t, tt.content)
+			if _, err := Load(tt.opts); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	clearEnv(t)
+	if _, err := Load(Options{Path: filepath.Join(t.TempDir(), "missing.yaml")}); err == nil {
+		t.Error("Load with an explicit missing path: want error")
+	}
+
+	// 默认路径的文件不存在时只使用环境变量
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	t.Setenv("FEISHU_APP_ID", "env_app")
+	cfg, err := Load(Options{})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.AppID != "env_app" {
+		t.Errorf("AppID = %q, want env_app", cfg.AppID)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	complete := Feishu{AppID: "cli_a1b2c3", AppSecret: "s3cr3t", AppToken: "bascnAbc", TableID: "tblAbc"}
+	tests := []struct {
+		name     string
+		cfg      Feishu
+		required []Field
+		want     []string // 错误信息中应包含的内容，为空表示校验通过
+	}{
+		{name: "完整配置", cfg: complete, required: []Field{AppID, AppSecret, AppToken, TableID}},
+		{name: "未要求的配置项可以为空", cfg: Feishu{AppID: "cli_a1b2c3", AppSecret: "s3cr3t"}, required: []Field{AppID, AppSecret}},
+		{
+			name:     "缺少配置项",
+			cfg:      Feishu{AppID: "cli_a1b2c3", AppToken: "  "},
+			required: []Field{AppID, AppSecret, AppToken},
+			want:     []string{"缺少配置项 app_secret", "FEISHU_APP_SECRET", "缺少配置项 app_token"},
+		},
+		{
+			name:     "中文占位值",
+			cfg:      Feishu{AppID: "cli_a1b2c3", AppSecret: "s3cr3t", AppToken: "你的app_token"},
+			required: []Field{AppID, AppSecret, AppToken},
+			want:     []string{"app_token 仍是示例值", "你的app_token"},
+		},
+		{
+			name:     "英文占位值",
+			cfg:      Feishu{AppID: "YOUR_APP_ID_HERE", AppSecret: "s3cr3t", TableID: "tblxxxxxxxx"},
+			required: []Field{AppID, AppSecret, TableID},
+			want:     []string{"app_id 仍是示例值", "table_id 仍是示例值"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate(tt.required...)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Errorf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Validate: want error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate err = %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestIsPlaceholder(t *testing.T) {
+	for v, want := range map[string]bool{
+		"你的app_id":           true,
+		"your_app_id_here":   true,
+		"Your_Table_ID_Here": true,
+		"bascnxxxxxxxx":      true,
+		"cli_a1b2c3d4e5":     false,
+		"bascnAbCdEf":        false,
+		"your_app":           false,
+	} {
+		if got := isPlaceholder(v); got != want {
+			t.Errorf("isPlaceholder(%q) = %v, want %v", v, got, want)
+		}
+	}
+}
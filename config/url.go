@@ -0,0 +1,74 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"feishu_bitable_demo/feishu"
+)
+
+// expandURLs 将配置项中的飞书链接展开为对应的 token 和 ID。
+// app_token 链接中的 table 参数仅在 table_id 未填写时使用；table_id 链接会在 app_token 未填写时一并设置 app_token。
+// 知识库链接需要调用接口才能换取 app_token，因此保留原链接，由 Resolve 处理。
+func (c *Feishu) expandURLs() error {
+	if feishu.IsURL(c.AppToken) {
+		loc, err := feishu.ParseURL(c.AppToken)
+		if err != nil {
+			return fmt.Errorf("app_token: %w", err)
+		}
+		switch {
+		case loc.AppToken != "":
+			c.AppToken = loc.AppToken
+		case loc.WikiToken == "":
+			return fmt.Errorf("app_token: 不是多维表格链接: %s", c.AppToken)
+		}
+		if c.TableID == "" {
+			c.TableID = loc.TableID
+		}
+	}
+
+	if feishu.IsURL(c.TableID) {
+		loc, err := feishu.ParseURL(c.TableID)
+		if err != nil {
+			return fmt.Errorf("table_id: %w", err)
+		}
+		if loc.TableID == "" {
+			return fmt.Errorf("table_id: 链接中没有 table 参数，请在多维表格中选中数据表后再复制链接: %s", c.TableID)
+		}
+		if c.AppToken == "" {
+			c.AppToken = loc.AppToken
+			if loc.AppToken == "" {
+				c.AppToken = c.TableID // 知识库链接，由 Resolve 解析
+			}
+		}
+		c.TableID = loc.TableID
+	}
+
+	if feishu.IsURL(c.FolderToken) {
+		loc, err := feishu.ParseURL(c.FolderToken)
+		if err != nil {
+			return fmt.Errorf("folder_token: %w", err)
+		}
+		if loc.FolderToken == "" {
+			return fmt.Errorf("folder_token: 不是云空间文件夹链接: %s", c.FolderToken)
+		}
+		c.FolderToken = loc.FolderToken
+	}
+	return nil
+}
+
+// Resolve 调用接口解析仍是知识库链接的 app_token（知识库中的多维表格），其他链接在 Load 时已展开
+func (c *Feishu) Resolve(ctx context.Context, client *feishu.MultiTableClient) error {
+	if !feishu.IsURL(c.AppToken) {
+		return nil
+	}
+	loc, err := client.ResolveURLContext(ctx, c.AppToken)
+	if err != nil {
+		return fmt.Errorf("解析 app_token 链接失败: %w", err)
+	}
+	if loc.AppToken == "" {
+		return fmt.Errorf("app_token 链接指向的不是多维表格: %s", c.AppToken)
+	}
+	c.AppToken = loc.AppToken
+	return nil
+}
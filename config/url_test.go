@@ -0,0 +1,120 @@
+package config
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"feishu_bitable_demo/feishu"
+	"feishu_bitable_demo/feishu/fakeserver"
+)
+
+func TestExpandURLs(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Feishu
+		want    Feishu
+		wantErr string
+	}{
+		{
+			name: "token 原样保留",
+			cfg:  Feishu{AppToken: "bascnAbc", TableID: "tblDef", FolderToken: "fldcnGhi"},
+			want: Feishu{AppToken: "bascnAbc", TableID: "tblDef", FolderToken: "fldcnGhi"},
+		},
+		{
+			name: "app_token 链接同时设置 table_id",
+			cfg:  Feishu{AppToken: "https://xxx.feishu.cn/base/bascnAbc?table=tblDef&view=vewGhi"},
+			want: Feishu{AppToken: "bascnAbc", TableID: "tblDef"},
+		},
+		{
+			name: "已填写的 table_id 优先于 app_token 链接中的 table 参数",
+			cfg:  Feishu{AppToken: "https://xxx.larksuite.com/base/bascnAbc?table=tblDef", TableID: "tblOther"},
+			want: Feishu{AppToken: "bascnAbc", TableID: "tblOther"},
+		},
+		{
+			name: "table_id 链接同时设置 app_token",
+			cfg:  Feishu{TableID: "https://xxx.feishu.cn/base/bascnAbc?table=tblDef"},
+			want: Feishu{AppToken: "bascnAbc", TableID: "tblDef"},
+		},
+		{
+			name: "table_id 链接不覆盖已填写的 app_token",
+			cfg:  Feishu{AppToken: "bascnMine", TableID: "https://xxx.feishu.cn/base/bascnAbc?table=tblDef"},
+			want: Feishu{AppToken: "bascnMine", TableID: "tblDef"},
+		},
+		{
+			name: "知识库链接留给 Resolve 处理",
+			cfg:  Feishu{AppToken: "https://xxx.feishu.cn/wiki/wikcnAbc?table=tblDef"},
+			want: Feishu{AppToken: "https://xxx.feishu.cn/wiki/wikcnAbc?table=tblDef", TableID: "tblDef"},
+		},
+		{
+			name: "table_id 为知识库链接",
+			cfg:  Feishu{TableID: "https://xxx.feishu.cn/wiki/wikcnAbc?table=tblDef"},
+			want: Feishu{AppToken: "https://xxx.feishu.cn/wiki/wikcnAbc?table=tblDef", TableID: "tblDef"},
+		},
+		{
+			name: "文件夹链接",
+			cfg:  Feishu{FolderToken: "https://xxx.feishu.cn/drive/folder/fldcnGhi"},
+			want: Feishu{FolderToken: "fldcnGhi"},
+		},
+		{name: "app_token 为云文档链接", cfg: Feishu{AppToken: "https://xxx.feishu.cn/docx/doxcnAbc"}, wantErr: "app_token: 不是多维表格链接"},
+		{name: "app_token 链接无法解析", cfg: Feishu{AppToken: "https://xxx.feishu.cn/sheets/shtcnAbc"}, wantErr: "app_token"},
+		{name: "table_id 链接没有 table 参数", cfg: Feishu{TableID: "https://xxx.feishu.cn/base/bascnAbc"}, wantErr: "table_id: 链接中没有 table 参数"},
+		{name: "folder_token 不是文件夹链接", cfg: Feishu{FolderToken: "https://xxx.feishu.cn/base/bascnAbc"}, wantErr: "folder_token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			err := cfg.expandURLs()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expandURLs err = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expandURLs: %v", err)
+			}
+			if cfg.AppToken != tt.want.AppToken || cfg.TableID != tt.want.TableID || cfg.FolderToken != tt.want.FolderToken {
+				t.Errorf("expandURLs = %+v, want %+v", cfg, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	srv := fakeserver.New()
+	t.Cleanup(srv.Close)
+	client := srv.NewClient()
+	bitableNode := srv.AddWikiNode(feishu.WikiObjTypeBitable, "bascnWiki", "产品库")
+	docxNode := srv.AddWikiNode(feishu.WikiObjTypeDocx, "doxcnWiki", "说明文档")
+
+	tests := []struct {
+		name     string
+		appToken string
+		want     string
+		wantErr  bool
+	}{
+		{name: "token 不调用接口", appToken: "bascnAbc", want: "bascnAbc"},
+		{name: "知识库中的多维表格", appToken: "https://xxx.feishu.cn/wiki/" + bitableNode, want: "bascnWiki"},
+		{name: "知识库中的云文档", appToken: "https://xxx.feishu.cn/wiki/" + docxNode, wantErr: true},
+		{name: "节点不存在", appToken: "https://xxx.feishu.cn/wiki/wikcnMissing", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Feishu{AppToken: tt.appToken}
+			err := cfg.Resolve(context.Background(), client)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Resolve: app_token = %q, want error", cfg.AppToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve: %v", err)
+			}
+			if cfg.AppToken != tt.want {
+				t.Errorf("app_token = %q, want %q", cfg.AppToken, tt.want)
+			}
+		})
+	}
+}
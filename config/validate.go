@@ -0,0 +1,63 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Field 配置项名称，与 config.yaml 中的键一致
+type Field string
+
+const (
+	AppID       Field = "app_id"
+	AppSecret   Field = "app_secret"
+	AppToken    Field = "app_token"
+	TableID     Field = "table_id"
+	FolderToken Field = "folder_token"
+)
+
+// value 返回配置项的值
+func (c *Feishu) value(f Field) string {
+	switch f {
+	case AppID:
+		return c.AppID
+	case AppSecret:
+		return c.AppSecret
+	case AppToken:
+		return c.AppToken
+	case TableID:
+		return c.TableID
+	case FolderToken:
+		return c.FolderToken
+	}
+	return ""
+}
+
+// envName 配置项对应的环境变量名
+func (f Field) envName() string {
+	return "FEISHU_" + strings.ToUpper(string(f))
+}
+
+// Validate 校验命令所需的配置项：必须填写，且不能是 config.example.yaml 中的示例值
+func (c *Feishu) Validate(required ...Field) error {
+	var errs []error
+	for _, f := range required {
+		v := strings.TrimSpace(c.value(f))
+		switch {
+		case v == "":
+			errs = append(errs, fmt.Errorf("缺少配置项 %s，请在 config.yaml 的 feishu.%s 中填写，或设置环境变量 %s", f, f, f.envName()))
+		case isPlaceholder(v):
+			errs = append(errs, fmt.Errorf("配置项 %s 仍是示例值 %q，请替换为飞书开放平台中的真实值", f, v))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isPlaceholder 判断是否为示例配置中的占位值，如 "你的app_id"、"your_app_id_here"
+func isPlaceholder(v string) bool {
+	lower := strings.ToLower(v)
+	return strings.HasPrefix(v, "你的") ||
+		(strings.HasPrefix(lower, "your_") && strings.HasSuffix(lower, "_here")) ||
+		strings.Contains(lower, "xxxxxx")
+}
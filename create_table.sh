@@ -44,7 +44,7 @@ echo ""
 # 运行创建表格程序
 echo "🔨 正在创建多维表格并写入数据..."
 echo ""
-go run main_create.go
+go run ./cmd/feishu create "$@"
 
 echo ""
 echo "=================================================="
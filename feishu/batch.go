@@ -0,0 +1,159 @@
+package feishu
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MaxBatchSize 批量接口单次请求允许的最大记录数，超过时自动分批请求
+const MaxBatchSize = 500
+
+// WithBatchConcurrency 设置批量接口分批后同时发出的最大请求数，默认为 1（逐批顺序请求）。
+// 并发请求仍受客户端限流控制。
+func WithBatchConcurrency(n int) Option {
+	return func(o *clientOptions) {
+		o.batchConcurrency = max(n, 1)
+	}
+}
+
+// ChunkError 一批请求的失败信息，对应输入切片中 [Start, End) 范围内的记录
+type ChunkError struct {
+	Start int
+	End   int
+	Err   error
+}
+
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("第 %d-%d 条记录: %v", e.Start+1, e.End, e.Err)
+}
+
+func (e *ChunkError) Unwrap() error { return e.Err }
+
+// BatchError 分批请求中部分批次失败，其余批次已成功写入。
+// 可以通过 errors.Is 判断其中是否包含 ErrRateLimited 等错误。
+type BatchError struct {
+	Op     string        // 操作名称，如 "批量创建记录"
+	Total  int           // 记录总数
+	Chunks []*ChunkError // 失败的批次，按 Start 排序
+}
+
+func (e *BatchError) Error() string {
+	failed := 0
+	msgs := make([]string, 0, len(e.Chunks))
+	for _, chunk := range e.Chunks {
+		failed += chunk.End - chunk.Start
+		msgs = append(msgs, chunk.Error())
+	}
+	return fmt.Sprintf("%s部分失败（%d/%d 条记录未写入）: %s", e.Op, failed, e.Total, strings.Join(msgs, "; "))
+}
+
+// Unwrap 返回每个失败批次的错误，支持 errors.Is 和 errors.As
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Chunks))
+	for _, chunk := range e.Chunks {
+		errs = append(errs, chunk)
+	}
+	return errs
+}
+
+// BatchResult 批量写入的结果
+type BatchResult struct {
+	// Records 与输入顺序一致的写入后的记录，写入失败的位置为 nil
+	Records []*Record
+	// Failed 写入失败的记录，按输入顺序排列。
+	// 飞书的批量接口整批生效或整批失败，同一批次中的记录失败原因相同。
+	Failed []*RecordError
+}
+
+// RecordError 单条记录写入失败的原因
+type RecordError struct {
+	Index    int    // 在输入中的位置
+	RecordID string // 更新时为记录 ID，创建时为空
+	Err      error
+}
+
+func (e *RecordError) Error() string {
+	if e.RecordID != "" {
+		return fmt.Sprintf("记录 %s: %v", e.RecordID, e.Err)
+	}
+	return fmt.Sprintf("第 %d 条记录: %v", e.Index+1, e.Err)
+}
+
+func (e *RecordError) Unwrap() error { return e.Err }
+
+// Succeeded 返回写入成功的记录 ID，按输入顺序排列
+func (r *BatchResult) Succeeded() []string {
+	ids := make([]string, 0, len(r.Records))
+	for _, record := range r.Records {
+		if record != nil {
+			ids = append(ids, record.ID)
+		}
+	}
+	return ids
+}
+
+// addFailures 将 runChunks 返回的错误展开为每条记录的失败信息，recordID 返回输入中第 i 条记录的 ID
+func (r *BatchResult) addFailures(err error, recordID func(i int) string) {
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		return
+	}
+	for _, chunk := range batchErr.Chunks {
+		for i := chunk.Start; i < chunk.End; i++ {
+			r.Failed = append(r.Failed, &RecordError{Index: i, RecordID: recordID(i), Err: chunk.Err})
+		}
+	}
+}
+
+// runChunks 将 [0, total) 按 MaxBatchSize 分批执行 fn，最多同时执行 c.batchConcurrency 批。
+// 单批失败不影响其他批次；ctx 取消后尚未开始的批次以 ctx.Err() 记为失败。
+// 全部成功时返回 nil，否则返回 *BatchError。
+func (c *MultiTableClient) runChunks(ctx context.Context, op string, total int, fn func(start, end int) error) error {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		failed []*ChunkError
+		sem    = make(chan struct{}, max(c.batchConcurrency, 1))
+	)
+	fail := func(start, end int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failed = append(failed, &ChunkError{Start: start, End: end, Err: err})
+	}
+
+	for start := 0; start < total; start += MaxBatchSize {
+		end := min(start+MaxBatchSize, total)
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			fail(start, end, ctx.Err())
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			<-sem
+			fail(start, end, err)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(start, end); err != nil {
+				fail(start, end, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(failed) == 0 {
+		return nil
+	}
+	slices.SortFunc(failed, func(a, b *ChunkError) int { return cmp.Compare(a.Start, b.Start) })
+	return &BatchError{Op: op, Total: total, Chunks: failed}
+}
@@ -0,0 +1,95 @@
+package feishu_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"feishu_bitable_demo/feishu"
+)
+
+func TestBatchCreateRecordsChunks(t *testing.T) {
+	for _, concurrency := range []int{1, 3} {
+		t.Run(fmt.Sprintf("concurrency=%d", concurrency), func(t *testing.T) {
+			srv, client, appToken, tableID := newTestTable(t, feishu.WithBatchConcurrency(concurrency))
+			records := newRecords(1203)
+
+			before := srv.RequestCount()
+			result, err := client.BatchCreateRecords(appToken, tableID, records)
+			if err != nil {
+				t.Fatalf("BatchCreateRecords: %v", err)
+			}
+			if n := srv.RequestCount() - before; n != 3 {
+				t.Errorf("sent %d requests, want 3 chunks of at most %d", n, feishu.MaxBatchSize)
+			}
+			if len(result.Records) != len(records) || len(result.Succeeded()) != len(records) {
+				t.Fatalf("got %d records, %d succeeded; want %d", len(result.Records), len(result.Succeeded()), len(records))
+			}
+			for i, record := range result.Records {
+				if record.Fields["数量"] != float64(i+1) {
+					t.Fatalf("Records[%d].数量 = %v, want %d (results must follow input order)", i, record.Fields["数量"], i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchCreateRecordsPartialFailure(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t, feishu.WithoutRetry())
+	records := newRecords(1203)
+	records[1100].Fields["数量"] = "很多" // 最后一批因数字字段的值无效而失败
+	srv.FailNext(1, http.StatusBadRequest, 1254001, "WrongRequestBody", nil)
+
+	result, err := client.BatchCreateRecords(appToken, tableID, records)
+	var batchErr *feishu.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("err = %v, want *BatchError", err)
+	}
+	if batchErr.Total != 1203 || len(batchErr.Chunks) != 2 {
+		t.Fatalf("BatchError = %+v, want 2 failed chunks of 1203", batchErr)
+	}
+	for i, want := range [][2]int{{0, 500}, {1000, 1203}} {
+		if chunk := batchErr.Chunks[i]; chunk.Start != want[0] || chunk.End != want[1] {
+			t.Errorf("Chunks[%d] = [%d, %d), want [%d, %d)", i, chunk.Start, chunk.End, want[0], want[1])
+		}
+	}
+	if !errors.Is(err, feishu.ErrInvalidField) {
+		t.Errorf("errors.Is(err, ErrInvalidField) = false, want the last chunk's cause to be reachable")
+	}
+
+	if n := len(result.Succeeded()); n != 500 {
+		t.Errorf("%d records succeeded, want 500", n)
+	}
+	if len(result.Failed) != 703 || result.Failed[0].Index != 0 || result.Failed[500].Index != 1000 {
+		t.Errorf("Failed has %d entries, want 703 covering indexes 0-499 and 1000-1202", len(result.Failed))
+	}
+	for i, record := range result.Records {
+		if ok := record != nil; ok != (i >= 500 && i < 1000) {
+			t.Fatalf("Records[%d] = %v, want only the middle chunk to be written", i, record)
+		}
+	}
+	written := srv.Records(appToken, tableID)
+	if len(written) != 500 || written[0]["数量"] != 501.0 {
+		t.Errorf("server has %d records starting at %v, want records 501-1000", len(written), written[0]["数量"])
+	}
+}
+
+func TestBatchDeleteRecordsChunks(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t)
+	result, err := client.BatchCreateRecords(appToken, tableID, newRecords(1203))
+	if err != nil {
+		t.Fatalf("BatchCreateRecords: %v", err)
+	}
+
+	before := srv.RequestCount()
+	if err := client.BatchDeleteRecords(appToken, tableID, result.Succeeded()); err != nil {
+		t.Fatalf("BatchDeleteRecords: %v", err)
+	}
+	if n := srv.RequestCount() - before; n != 3 {
+		t.Errorf("sent %d requests, want 3", n)
+	}
+	if n := len(srv.Records(appToken, tableID)); n != 0 {
+		t.Errorf("%d records left, want 0", n)
+	}
+}
@@ -1,21 +1,101 @@
 package feishu
 
 import (
+	"net/http"
+	"strings"
+	"time"
+
 	lark "github.com/larksuite/oapi-sdk-go/v3"
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
 )
 
 // MultiTableClient 飞书多维表格客户端
 type MultiTableClient struct {
-	client *lark.Client
+	client  *lark.Client
+	retry   RetryPolicy
+	limiter *rateLimiter
+
+	batchConcurrency int
+}
+
+// Option 客户端配置项
+type Option func(*clientOptions)
+
+// clientOptions 创建客户端时收集的配置
+type clientOptions struct {
+	larkOptions []lark.ClientOptionFunc
+	retry       RetryPolicy
+	rateLimits  map[Endpoint]RateLimit
+
+	batchConcurrency int
+}
+
+// WithBaseURL 设置开放平台地址，可用于企业代理或本地测试服务器（如 httptest）
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.larkOptions = append(o.larkOptions, lark.WithOpenBaseUrl(strings.TrimRight(baseURL, "/")))
+	}
+}
+
+// WithLarkDomain 使用国际版 Lark 域名（open.larksuite.com）
+func WithLarkDomain() Option {
+	return WithBaseURL(lark.LarkBaseUrl)
+}
+
+// WithHTTPClient 使用自定义 HTTP 客户端（代理、TLS 等）
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) {
+		if httpClient == nil {
+			return
+		}
+		o.larkOptions = append(o.larkOptions, lark.WithHttpClient(httpClient))
+	}
+}
+
+// WithRequestTimeout 设置单次请求超时时间
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.larkOptions = append(o.larkOptions, lark.WithReqTimeout(timeout))
+	}
+}
+
+// WithLogLevel 设置 SDK 日志级别
+func WithLogLevel(level larkcore.LogLevel) Option {
+	return func(o *clientOptions) {
+		o.larkOptions = append(o.larkOptions, lark.WithLogLevel(level))
+	}
+}
+
+// WithMarketplaceApp 声明为应用商店应用（默认为企业自建应用）
+func WithMarketplaceApp() Option {
+	return func(o *clientOptions) {
+		o.larkOptions = append(o.larkOptions, lark.WithMarketplaceApp())
+	}
 }
 
 // NewMultiTableClient 新建客户端
-func NewMultiTableClient(appID, appSecret string) *MultiTableClient {
+func NewMultiTableClient(appID, appSecret string, opts ...Option) *MultiTableClient {
+	options := &clientOptions{
+		retry:            DefaultRetryPolicy,
+		rateLimits:       make(map[Endpoint]RateLimit, len(DefaultRateLimits)),
+		batchConcurrency: 1,
+	}
+	for endpoint, limit := range DefaultRateLimits {
+		options.rateLimits[endpoint] = limit
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	// 使用官方 SDK 创建客户端
-	client := lark.NewClient(appID, appSecret)
+	client := lark.NewClient(appID, appSecret, options.larkOptions...)
 
 	return &MultiTableClient{
-		client: client,
+		client:  client,
+		retry:   options.retry,
+		limiter: newRateLimiter(options.rateLimits),
+
+		batchConcurrency: options.batchConcurrency,
 	}
 }
 
@@ -0,0 +1,266 @@
+package feishu
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// GeoLocation 地理位置字段的值
+type GeoLocation struct {
+	Location    string `json:"location"` // 经纬度，如 "116.397755,39.903179"
+	Name        string `json:"name,omitempty"`
+	Address     string `json:"address,omitempty"`
+	FullAddress string `json:"full_address,omitempty"`
+	Province    string `json:"pname,omitempty"`
+	City        string `json:"cityname,omitempty"`
+	District    string `json:"adname,omitempty"`
+}
+
+// DecodeError 字段值与期望的类型不匹配
+type DecodeError struct {
+	Want  string      // 期望的字段类型，如 "文本"、"日期"
+	Value interface{} // 实际的字段值
+}
+
+func (e *DecodeError) Error() string {
+	data, err := json.Marshal(e.Value)
+	if err != nil {
+		data = []byte(fmt.Sprint(e.Value))
+	}
+	return fmt.Sprintf("字段值 %s 不是%s", data, e.Want)
+}
+
+// 以下 As 函数将查询记录时返回的字段值（Record.Fields 中的值）解码为对应的 Go 类型，
+// 与 CreateTextField 等写入函数相对应。字段值为 nil（空字段）时返回零值；
+// 公式、查找引用字段会先取出其中的值再解码；值与期望的类型不匹配时返回 *DecodeError。
+
+// AsText 解码文本类字段：文本（富文本片段拼接为字符串）、单选、电话号码、自动编号、邮箱等，
+// 超链接字段返回其显示文本
+func AsText(v interface{}) (string, error) {
+	raw := unwrapFormula(normalizeValue(v), false)
+	if raw == nil {
+		return "", nil
+	}
+	s, ok := textFromValue(raw)
+	if !ok {
+		return "", &DecodeError{Want: "文本", Value: v}
+	}
+	return s, nil
+}
+
+// AsNumber 解码数字类字段：数字、货币、进度、评分等
+func AsNumber(v interface{}) (float64, error) {
+	raw := unwrapFormula(normalizeValue(v), false)
+	if raw == nil {
+		return 0, nil
+	}
+	n, ok := raw.(float64)
+	if !ok {
+		return 0, &DecodeError{Want: "数字", Value: v}
+	}
+	return n, nil
+}
+
+// AsTime 解码日期类字段：日期、创建时间、最后更新时间（毫秒时间戳）
+func AsTime(v interface{}) (time.Time, error) {
+	raw := unwrapFormula(normalizeValue(v), false)
+	if raw == nil {
+		return time.Time{}, nil
+	}
+	ms, ok := raw.(float64)
+	if !ok {
+		return time.Time{}, &DecodeError{Want: "日期（毫秒时间戳）", Value: v}
+	}
+	return time.UnixMilli(int64(ms)), nil
+}
+
+// AsBool 解码复选框字段
+func AsBool(v interface{}) (bool, error) {
+	raw := unwrapFormula(normalizeValue(v), false)
+	if raw == nil {
+		return false, nil
+	}
+	b, ok := raw.(bool)
+	if !ok {
+		return false, &DecodeError{Want: "复选框", Value: v}
+	}
+	return b, nil
+}
+
+// AsUsers 解码人员类字段：人员、创建人、修改人
+func AsUsers(v interface{}) ([]User, error) {
+	var users []User
+	if err := decodeObjects(v, "人员", &users, "id"); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// AsURL 解码超链接字段，空字段返回 nil
+func AsURL(v interface{}) (*Link, error) {
+	raw := unwrapFormula(normalizeValue(v), false)
+	if raw == nil {
+		return nil, nil
+	}
+	m, ok := raw.(map[string]interface{})
+	if _, hasLink := m["link"].(string); !ok || !hasLink {
+		return nil, &DecodeError{Want: "超链接", Value: v}
+	}
+	var link Link
+	if err := remarshal(m, &link); err != nil {
+		return nil, &DecodeError{Want: "超链接", Value: v}
+	}
+	return &link, nil
+}
+
+// AsAttachments 解码附件字段
+func AsAttachments(v interface{}) ([]Attachment, error) {
+	var attachments []Attachment
+	if err := decodeObjects(v, "附件", &attachments, "file_token"); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// AsOptions 解码单选、多选字段，返回选中的选项名称；单选字段返回只有一个元素的切片
+func AsOptions(v interface{}) ([]string, error) {
+	raw := unwrapFormula(normalizeValue(v), true)
+	switch raw := raw.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{raw}, nil
+	case []interface{}:
+		options := make([]string, 0, len(raw))
+		for _, item := range raw {
+			s, ok := textFromValue(item)
+			if !ok {
+				return nil, &DecodeError{Want: "单选或多选", Value: v}
+			}
+			options = append(options, s)
+		}
+		return options, nil
+	}
+	return nil, &DecodeError{Want: "单选或多选", Value: v}
+}
+
+// AsLinkedRecordIDs 解码单向关联、双向关联字段，返回关联记录的 record_id。
+// 支持 {"link_record_ids": [...]}、[{"record_ids": [...], ...}] 两种读取格式以及写入时的 record_id 数组。
+func AsLinkedRecordIDs(v interface{}) ([]string, error) {
+	raw := unwrapFormula(normalizeValue(v), true)
+	mismatch := &DecodeError{Want: "关联", Value: v}
+	switch raw := raw.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		ids, ok := stringSlice(raw["link_record_ids"])
+		if !ok {
+			return nil, mismatch
+		}
+		return ids, nil
+	case []interface{}:
+		var ids []string
+		for _, item := range raw {
+			switch item := item.(type) {
+			case string:
+				ids = append(ids, item)
+			case map[string]interface{}:
+				recordIDs, ok := stringSlice(item["record_ids"])
+				if !ok {
+					return nil, mismatch
+				}
+				ids = append(ids, recordIDs...)
+			default:
+				return nil, mismatch
+			}
+		}
+		return ids, nil
+	}
+	return nil, mismatch
+}
+
+// AsLocation 解码地理位置字段，空字段返回 nil
+func AsLocation(v interface{}) (*GeoLocation, error) {
+	raw := unwrapFormula(normalizeValue(v), false)
+	if raw == nil {
+		return nil, nil
+	}
+	m, ok := raw.(map[string]interface{})
+	if _, hasLocation := m["location"].(string); !ok || !hasLocation {
+		return nil, &DecodeError{Want: "地理位置", Value: v}
+	}
+	var location GeoLocation
+	if err := remarshal(m, &location); err != nil {
+		return nil, &DecodeError{Want: "地理位置", Value: v}
+	}
+	return &location, nil
+}
+
+// unwrapFormula 取出公式、查找引用字段 {"type": 字段类型, "value": [...]} 中的值；
+// multiple 为 false 时只有一个值的数组会展开为该值
+func unwrapFormula(raw interface{}, multiple bool) interface{} {
+	m, ok := raw.(map[string]interface{})
+	if !ok || m["value"] == nil || m["type"] == nil {
+		return raw
+	}
+	raw = m["value"]
+	if values, ok := raw.([]interface{}); ok && len(values) == 1 && !multiple {
+		return values[0]
+	}
+	return raw
+}
+
+// decodeObjects 将对象数组（或单个对象）解码到 out，每个对象都必须包含 key
+func decodeObjects(v interface{}, want string, out interface{}, key string) error {
+	raw := unwrapFormula(normalizeValue(v), true)
+	if raw == nil {
+		return nil
+	}
+	if m, ok := raw.(map[string]interface{}); ok {
+		raw = []interface{}{m}
+	}
+	items, ok := raw.([]interface{})
+	if !ok {
+		return &DecodeError{Want: want, Value: v}
+	}
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return &DecodeError{Want: want, Value: v}
+		}
+		if _, ok := m[key].(string); !ok {
+			return &DecodeError{Want: want, Value: v}
+		}
+	}
+	if err := remarshal(items, out); err != nil {
+		return &DecodeError{Want: want, Value: v}
+	}
+	return nil
+}
+
+// remarshal 经过 JSON 将 v 转换到 out
+func remarshal(v, out interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// stringSlice 将 JSON 解码后的字符串数组转换为 []string
+func stringSlice(v interface{}) ([]string, bool) {
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, s)
+	}
+	return out, true
+}
@@ -0,0 +1,117 @@
+package feishu_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"feishu_bitable_demo/feishu"
+)
+
+// jsonValue 将 JSON 文本解码为查询记录时 Record.Fields 中的字段值
+func jsonValue(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("invalid JSON %s: %v", s, err)
+	}
+	return v
+}
+
+func TestDecode(t *testing.T) {
+	asText := func(v interface{}) (interface{}, error) { return feishu.AsText(v) }
+	asNumber := func(v interface{}) (interface{}, error) { return feishu.AsNumber(v) }
+	asTime := func(v interface{}) (interface{}, error) { return feishu.AsTime(v) }
+	asBool := func(v interface{}) (interface{}, error) { return feishu.AsBool(v) }
+	asUsers := func(v interface{}) (interface{}, error) { return feishu.AsUsers(v) }
+	asURL := func(v interface{}) (interface{}, error) { return feishu.AsURL(v) }
+	asAttachments := func(v interface{}) (interface{}, error) { return feishu.AsAttachments(v) }
+	asOptions := func(v interface{}) (interface{}, error) { return feishu.AsOptions(v) }
+	asLinks := func(v interface{}) (interface{}, error) { return feishu.AsLinkedRecordIDs(v) }
+	asLocation := func(v interface{}) (interface{}, error) { return feishu.AsLocation(v) }
+
+	tests := []struct {
+		name    string
+		decode  func(interface{}) (interface{}, error)
+		in      string // 字段值的 JSON 文本
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "文本/富文本", decode: asText, in: `[{"type":"text","text":"你好，"},{"type":"mention","text":"@张三"}]`, want: "你好，@张三"},
+		{name: "文本/字符串", decode: asText, in: `"13800000000"`, want: "13800000000"},
+		{name: "文本/空", decode: asText, in: `null`, want: ""},
+		{name: "文本/公式", decode: asText, in: `{"type":1,"value":[{"type":"text","text":"合计"}]}`, want: "合计"},
+		{name: "文本/类型不匹配", decode: asText, in: `true`, wantErr: true},
+
+		{name: "数字", decode: asNumber, in: `12.5`, want: 12.5},
+		{name: "数字/空", decode: asNumber, in: `null`, want: 0.0},
+		{name: "数字/公式", decode: asNumber, in: `{"type":2,"value":[55]}`, want: 55.0},
+		{name: "数字/类型不匹配", decode: asNumber, in: `"12"`, wantErr: true},
+		{name: "数字/公式多个值", decode: asNumber, in: `{"type":2,"value":[1,2]}`, wantErr: true},
+
+		{name: "日期", decode: asTime, in: `1700000000000`, want: time.UnixMilli(1700000000000)},
+		{name: "日期/空", decode: asTime, in: `null`, want: time.Time{}},
+		{name: "日期/查找引用", decode: asTime, in: `{"type":5,"value":[1700000000000]}`, want: time.UnixMilli(1700000000000)},
+		{name: "日期/类型不匹配", decode: asTime, in: `"2023-11-14"`, wantErr: true},
+
+		{name: "复选框", decode: asBool, in: `true`, want: true},
+		{name: "复选框/空", decode: asBool, in: `null`, want: false},
+		{name: "复选框/公式", decode: asBool, in: `{"type":7,"value":[true]}`, want: true},
+		{name: "复选框/类型不匹配", decode: asBool, in: `1`, wantErr: true},
+
+		{name: "人员", decode: asUsers, in: `[{"id":"ou_1","name":"张三"},{"id":"ou_2"}]`, want: []feishu.User{{ID: "ou_1", Name: "张三"}, {ID: "ou_2"}}},
+		{name: "人员/创建人", decode: asUsers, in: `{"id":"ou_1","name":"张三"}`, want: []feishu.User{{ID: "ou_1", Name: "张三"}}},
+		{name: "人员/空", decode: asUsers, in: `null`, want: []feishu.User(nil)},
+		{name: "人员/查找引用", decode: asUsers, in: `{"type":11,"value":[{"id":"ou_1"},{"id":"ou_2"}]}`, want: []feishu.User{{ID: "ou_1"}, {ID: "ou_2"}}},
+		{name: "人员/缺少 id", decode: asUsers, in: `[{"name":"张三"}]`, wantErr: true},
+
+		{name: "超链接", decode: asURL, in: `{"text":"飞书","link":"https://www.feishu.cn"}`, want: &feishu.Link{Text: "飞书", URL: "https://www.feishu.cn"}},
+		{name: "超链接/空", decode: asURL, in: `null`, want: (*feishu.Link)(nil)},
+		{name: "超链接/类型不匹配", decode: asURL, in: `"https://www.feishu.cn"`, wantErr: true},
+
+		{name: "附件", decode: asAttachments, in: `[{"file_token":"box1","name":"a.png","size":1024}]`, want: []feishu.Attachment{{FileToken: "box1", Name: "a.png", Size: 1024}}},
+		{name: "附件/空", decode: asAttachments, in: `null`, want: []feishu.Attachment(nil)},
+		{name: "附件/缺少 file_token", decode: asAttachments, in: `[{"name":"a.png"}]`, wantErr: true},
+
+		{name: "单选", decode: asOptions, in: `"在售"`, want: []string{"在售"}},
+		{name: "多选", decode: asOptions, in: `["红","蓝"]`, want: []string{"红", "蓝"}},
+		{name: "选项/空", decode: asOptions, in: `null`, want: []string(nil)},
+		{name: "选项/查找引用", decode: asOptions, in: `{"type":4,"value":["红"]}`, want: []string{"红"}},
+		{name: "选项/类型不匹配", decode: asOptions, in: `3`, wantErr: true},
+
+		{name: "关联", decode: asLinks, in: `{"link_record_ids":["rec1","rec2"]}`, want: []string{"rec1", "rec2"}},
+		{name: "关联/记录数组", decode: asLinks, in: `[{"record_ids":["rec1"],"text":"a"},{"record_ids":["rec2"],"text":"b"}]`, want: []string{"rec1", "rec2"}},
+		{name: "关联/写入格式", decode: asLinks, in: `["rec1"]`, want: []string{"rec1"}},
+		{name: "关联/空", decode: asLinks, in: `null`, want: []string(nil)},
+		{name: "关联/类型不匹配", decode: asLinks, in: `{"link_record_ids":"rec1"}`, wantErr: true},
+
+		{name: "地理位置", decode: asLocation, in: `{"location":"116.397755,39.903179","name":"天安门","cityname":"北京市"}`,
+			want: &feishu.GeoLocation{Location: "116.397755,39.903179", Name: "天安门", City: "北京市"}},
+		{name: "地理位置/空", decode: asLocation, in: `null`, want: (*feishu.GeoLocation)(nil)},
+		{name: "地理位置/类型不匹配", decode: asLocation, in: `{"name":"天安门"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := jsonValue(t, tt.in)
+			got, err := tt.decode(in)
+			if tt.wantErr {
+				var decodeErr *feishu.DecodeError
+				if !errors.As(err, &decodeErr) {
+					t.Fatalf("decode(%s) = %v, %v; want *DecodeError", tt.in, got, err)
+				}
+				if !reflect.DeepEqual(decodeErr.Value, in) {
+					t.Errorf("DecodeError.Value = %v, want the original value %v", decodeErr.Value, in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decode(%s): %v", tt.in, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decode(%s) = %#v, want %#v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -12,6 +12,11 @@ type Block = larkdocx.Block
 
 // CreateDocument 创建云文档
 func (c *MultiTableClient) CreateDocument(title, folderToken string) (*larkdocx.CreateDocumentResp, error) {
+	return c.CreateDocumentContext(context.Background(), title, folderToken)
+}
+
+// CreateDocumentContext 创建云文档（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) CreateDocumentContext(ctx context.Context, title, folderToken string) (*larkdocx.CreateDocumentResp, error) {
 	// 构建请求
 	reqBuilder := larkdocx.NewCreateDocumentReqBuilder()
 	bodyBuilder := larkdocx.NewCreateDocumentReqBodyBuilder().Title(title)
@@ -23,14 +28,20 @@ func (c *MultiTableClient) CreateDocument(title, folderToken string) (*larkdocx.
 	req := reqBuilder.Body(bodyBuilder.Build()).Build()
 
 	// 发起请求
-	resp, err := c.client.Docx.Document.Create(context.Background(), req)
+	var resp *larkdocx.CreateDocumentResp
+	err := c.do(ctx, EndpointDefault, false, func() error {
+		var err error
+		resp, err = c.client.Docx.Document.Create(ctx, req)
+		if err != nil {
+			return fmt.Errorf("创建云文档失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("创建云文档", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("创建云文档失败: %w", err)
-	}
-
-	// 检查响应
-	if !resp.Success() {
-		return nil, fmt.Errorf("创建云文档失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, err
 	}
 
 	return resp, nil
@@ -38,20 +49,31 @@ func (c *MultiTableClient) CreateDocument(title, folderToken string) (*larkdocx.
 
 // GetDocument 获取云文档基本信息
 func (c *MultiTableClient) GetDocument(documentID string) (*larkdocx.GetDocumentResp, error) {
+	return c.GetDocumentContext(context.Background(), documentID)
+}
+
+// GetDocumentContext 获取云文档基本信息（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) GetDocumentContext(ctx context.Context, documentID string) (*larkdocx.GetDocumentResp, error) {
 	// 构建请求
 	req := larkdocx.NewGetDocumentReqBuilder().
 		DocumentId(documentID).
 		Build()
 
 	// 发起请求
-	resp, err := c.client.Docx.Document.Get(context.Background(), req)
+	var resp *larkdocx.GetDocumentResp
+	err := c.do(ctx, EndpointDefault, true, func() error {
+		var err error
+		resp, err = c.client.Docx.Document.Get(ctx, req)
+		if err != nil {
+			return fmt.Errorf("获取云文档信息失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("获取云文档信息", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("获取云文档信息失败: %w", err)
-	}
-
-	// 检查响应
-	if !resp.Success() {
-		return nil, fmt.Errorf("获取云文档信息失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, err
 	}
 
 	return resp, nil
@@ -59,20 +81,31 @@ func (c *MultiTableClient) GetDocument(documentID string) (*larkdocx.GetDocument
 
 // GetDocumentRawContent 获取云文档纯文本内容
 func (c *MultiTableClient) GetDocumentRawContent(documentID string) (*larkdocx.RawContentDocumentResp, error) {
+	return c.GetDocumentRawContentContext(context.Background(), documentID)
+}
+
+// GetDocumentRawContentContext 获取云文档纯文本内容（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) GetDocumentRawContentContext(ctx context.Context, documentID string) (*larkdocx.RawContentDocumentResp, error) {
 	// 构建请求
 	req := larkdocx.NewRawContentDocumentReqBuilder().
 		DocumentId(documentID).
 		Build()
 
 	// 发起请求
-	resp, err := c.client.Docx.Document.RawContent(context.Background(), req)
+	var resp *larkdocx.RawContentDocumentResp
+	err := c.do(ctx, EndpointDefault, true, func() error {
+		var err error
+		resp, err = c.client.Docx.Document.RawContent(ctx, req)
+		if err != nil {
+			return fmt.Errorf("获取云文档内容失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("获取云文档内容", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("获取云文档内容失败: %w", err)
-	}
-
-	// 检查响应
-	if !resp.Success() {
-		return nil, fmt.Errorf("获取云文档内容失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, err
 	}
 
 	return resp, nil
@@ -80,20 +113,31 @@ func (c *MultiTableClient) GetDocumentRawContent(documentID string) (*larkdocx.R
 
 // ListDocumentBlocks 获取云文档所有块（blocks）
 func (c *MultiTableClient) ListDocumentBlocks(documentID string) (*larkdocx.ListDocumentBlockResp, error) {
+	return c.ListDocumentBlocksContext(context.Background(), documentID)
+}
+
+// ListDocumentBlocksContext 获取云文档所有块（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) ListDocumentBlocksContext(ctx context.Context, documentID string) (*larkdocx.ListDocumentBlockResp, error) {
 	// 构建请求
 	req := larkdocx.NewListDocumentBlockReqBuilder().
 		DocumentId(documentID).
 		Build()
 
 	// 发起请求
-	resp, err := c.client.Docx.DocumentBlock.List(context.Background(), req)
+	var resp *larkdocx.ListDocumentBlockResp
+	err := c.do(ctx, EndpointDefault, true, func() error {
+		var err error
+		resp, err = c.client.Docx.DocumentBlock.List(ctx, req)
+		if err != nil {
+			return fmt.Errorf("获取文档块列表失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("获取文档块列表", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("获取文档块列表失败: %w", err)
-	}
-
-	// 检查响应
-	if !resp.Success() {
-		return nil, fmt.Errorf("获取文档块列表失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, err
 	}
 
 	return resp, nil
@@ -122,6 +166,11 @@ func CreateTextBlock(text string) *larkdocx.Block {
 
 // CreateDocumentBlock 在云文档中创建块（插入子块）
 func (c *MultiTableClient) CreateDocumentBlock(documentID, blockID string, index int, children []*larkdocx.Block) (*larkdocx.CreateDocumentBlockChildrenResp, error) {
+	return c.CreateDocumentBlockContext(context.Background(), documentID, blockID, index, children)
+}
+
+// CreateDocumentBlockContext 在云文档中创建块（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) CreateDocumentBlockContext(ctx context.Context, documentID, blockID string, index int, children []*larkdocx.Block) (*larkdocx.CreateDocumentBlockChildrenResp, error) {
 	// 构建请求
 	req := larkdocx.NewCreateDocumentBlockChildrenReqBuilder().
 		DocumentId(documentID).
@@ -135,14 +184,20 @@ func (c *MultiTableClient) CreateDocumentBlock(documentID, blockID string, index
 		Build()
 
 	// 发起请求
-	resp, err := c.client.Docx.DocumentBlockChildren.Create(context.Background(), req)
+	var resp *larkdocx.CreateDocumentBlockChildrenResp
+	err := c.do(ctx, EndpointDocxBlock, false, func() error {
+		var err error
+		resp, err = c.client.Docx.DocumentBlockChildren.Create(ctx, req)
+		if err != nil {
+			return fmt.Errorf("创建文档块失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("创建文档块", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("创建文档块失败: %w", err)
-	}
-
-	// 检查响应
-	if !resp.Success() {
-		return nil, fmt.Errorf("创建文档块失败: code=%d, msg=%s", resp.Code, resp.Msg)
+		return nil, err
 	}
 
 	return resp, nil
@@ -157,8 +212,13 @@ func CreateTextParagraph(content string) string {
 // UpdateDocumentBlock 更新文档块内容（通过追加新块的方式）
 // 注意：由于 SDK API 限制，这里使用追加新块的方式来"更新"内容
 func (c *MultiTableClient) UpdateDocumentBlock(documentID, parentBlockID string, newBlock *larkdocx.Block) error {
+	return c.UpdateDocumentBlockContext(context.Background(), documentID, parentBlockID, newBlock)
+}
+
+// UpdateDocumentBlockContext 更新文档块内容（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) UpdateDocumentBlockContext(ctx context.Context, documentID, parentBlockID string, newBlock *larkdocx.Block) error {
 	// 创建新块
-	_, err := c.CreateDocumentBlock(documentID, parentBlockID, -1, []*larkdocx.Block{newBlock})
+	_, err := c.CreateDocumentBlockContext(ctx, documentID, parentBlockID, -1, []*larkdocx.Block{newBlock})
 	if err != nil {
 		return fmt.Errorf("添加新块失败: %w", err)
 	}
@@ -0,0 +1,166 @@
+package feishu
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+// 常用错误类型，可配合 errors.Is 判断 APIError 的类别
+var (
+	ErrForbidden    = errors.New("feishu: permission denied")
+	ErrNotFound     = errors.New("feishu: resource not found")
+	ErrRateLimited  = errors.New("feishu: rate limited")
+	ErrInvalidField = errors.New("feishu: invalid field")
+	ErrConflict     = errors.New("feishu: record modified")
+)
+
+// 飞书开放平台错误码
+// 参考：https://open.feishu.cn/document/server-docs/docs/bitable-v1/bitable-overview
+const (
+	codeForbidden         = 91403    // 云空间无权限
+	codeNotExist          = 91402    // 云空间资源不存在
+	codeRateLimited       = 99991400 // 应用频率限制
+	codeAppNoPermission   = 99991672 // 应用未开通所需权限
+	codeWikiNodeNotFound  = 131005   // 知识库节点不存在
+	codeWikiForbidden     = 131006   // 知识库无权限
+	codeInvalidSort       = 1254017
+	codeInvalidFilter     = 1254018
+	codeBaseTokenNotFound = 1254040
+	codeTableNotFound     = 1254041
+	codeViewNotFound      = 1254042
+	codeRecordNotFound    = 1254043
+	codeFieldIDNotFound   = 1254044
+	codeFieldNameNotFound = 1254045
+	codeTooManyRequest    = 1254290
+	codeWriteConflict     = 1254291
+	codePermNotAllow      = 1254302
+	codeAttachPermDenied  = 1254303
+	codeDataNotReady      = 1254607
+	codeInternalError     = 1255001
+	codeRPCError          = 1255002
+	codeServerTimeout     = 1255040
+	codeDocNotFound       = 1770002
+	codeDocForbidden      = 1770032
+)
+
+// ConflictError 条件更新时记录已被修改，可通过 errors.Is(err, ErrConflict) 判断
+type ConflictError struct {
+	RecordID string
+	Reason   string  // 冲突原因，如 "最后修改时间 ... 晚于 ..."
+	Current  *Record // 冲突时记录的当前内容
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("记录 %s 已被修改: %s", e.RecordID, e.Reason)
+}
+
+// Is 支持 errors.Is(err, ErrConflict)
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// APIError 飞书接口返回的业务错误
+type APIError struct {
+	Op         string // 操作名称，如 "创建记录"
+	Code       int    // 飞书错误码
+	Msg        string // 飞书错误信息
+	LogID      string // 请求日志 ID，反馈问题时提供给飞书
+	HTTPStatus int    // HTTP 状态码
+
+	RetryAfter time.Duration // 被限流时服务端建议的等待时间（来自响应头）
+}
+
+// newAPIError 根据 SDK 响应构建 APIError
+func newAPIError(op string, apiResp *larkcore.ApiResp, codeErr larkcore.CodeError) *APIError {
+	e := &APIError{
+		Op:   op,
+		Code: codeErr.Code,
+		Msg:  codeErr.Msg,
+	}
+	if codeErr.Err != nil {
+		e.LogID = codeErr.Err.LogID
+	}
+	if apiResp != nil {
+		e.HTTPStatus = apiResp.StatusCode
+		e.RetryAfter = retryAfterFromHeader(apiResp.Header)
+		if e.LogID == "" {
+			e.LogID = apiResp.RequestId()
+		}
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	if e.LogID != "" {
+		return fmt.Sprintf("%s失败 [code=%d]: %s (log_id=%s)", e.Op, e.Code, e.Msg, e.LogID)
+	}
+	return fmt.Sprintf("%s失败 [code=%d]: %s", e.Op, e.Code, e.Msg)
+}
+
+// Is 支持 errors.Is(err, ErrForbidden) 等判断
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrForbidden:
+		return e.isForbidden()
+	case ErrNotFound:
+		return e.isNotFound()
+	case ErrRateLimited:
+		return e.isRateLimited()
+	case ErrInvalidField:
+		return e.isInvalidField()
+	}
+	return false
+}
+
+// Retryable 判断该错误是否为可重试的临时错误（限流、服务端错误等）
+func (e *APIError) Retryable() bool {
+	if e.isRateLimited() || e.HTTPStatus >= http.StatusInternalServerError {
+		return true
+	}
+	switch e.Code {
+	case codeWriteConflict, codeDataNotReady, codeInternalError, codeRPCError, codeServerTimeout:
+		return true
+	}
+	return false
+}
+
+func (e *APIError) isForbidden() bool {
+	switch e.Code {
+	case codeForbidden, codeAppNoPermission, codePermNotAllow, codeAttachPermDenied, codeDocForbidden,
+		codeWikiForbidden:
+		return true
+	}
+	return e.HTTPStatus == http.StatusForbidden
+}
+
+func (e *APIError) isNotFound() bool {
+	switch e.Code {
+	case codeNotExist, codeBaseTokenNotFound, codeTableNotFound, codeViewNotFound,
+		codeRecordNotFound, codeDocNotFound, codeWikiNodeNotFound:
+		return true
+	}
+	return e.HTTPStatus == http.StatusNotFound
+}
+
+func (e *APIError) isRateLimited() bool {
+	switch e.Code {
+	case codeRateLimited, codeTooManyRequest:
+		return true
+	}
+	return e.HTTPStatus == http.StatusTooManyRequests
+}
+
+func (e *APIError) isInvalidField() bool {
+	switch {
+	case e.Code == codeFieldIDNotFound, e.Code == codeFieldNameNotFound,
+		e.Code == codeInvalidSort, e.Code == codeInvalidFilter:
+		return true
+	case e.Code >= 1254060 && e.Code <= 1254074: // 各类字段值转换失败
+		return true
+	}
+	return false
+}
@@ -0,0 +1,630 @@
+package fakeserver
+
+import (
+	"net/http"
+	"slices"
+)
+
+// 字段类型（与飞书多维表格一致）
+const (
+	fieldTypeText     = 1
+	fieldTypeNumber   = 2
+	fieldTypeCheckbox = 7
+)
+
+type app struct {
+	token       string
+	name        string
+	folderToken string
+	tables      []*table
+}
+
+type table struct {
+	id           string
+	name         string
+	views        []*view
+	fields       []*field
+	records      []*record
+	clientTokens map[string][]*record // 按 client_token 记录已创建的记录，重复请求时直接返回
+	fieldTokens  map[string]*field    // 按 client_token 记录已创建的字段
+}
+
+type field struct {
+	ID          string                 `json:"field_id"`
+	Name        string                 `json:"field_name"`
+	Type        int                    `json:"type"`
+	UIType      string                 `json:"ui_type,omitempty"`
+	Property    map[string]interface{} `json:"property,omitempty"`
+	Primary     bool                   `json:"is_primary"`
+	Description string                 `json:"description,omitempty"` // 列出字段时返回字符串
+}
+
+type record struct {
+	id               string
+	fields           map[string]interface{}
+	createdTime      int64
+	lastModifiedTime int64
+}
+
+// json 转换为接口返回格式，automatic 对应 automatic_fields 参数
+func (rec *record) json(automatic bool) map[string]interface{} {
+	out := map[string]interface{}{
+		"id":        rec.id,
+		"record_id": rec.id,
+		"fields":    copyFields(rec.fields),
+	}
+	if automatic {
+		user := map[string]interface{}{"id": FakeUserID, "name": FakeUserName}
+		out["created_time"] = rec.createdTime
+		out["last_modified_time"] = rec.lastModifiedTime
+		out["created_by"] = user
+		out["last_modified_by"] = user
+	}
+	return out
+}
+
+func copyFields(fields map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		out[k] = v
+	}
+	return out
+}
+
+func (s *Server) registerBitable(mux *http.ServeMux) {
+	const base = "/open-apis/bitable/v1/apps"
+	mux.HandleFunc("POST "+base, s.handleCreateApp)
+	mux.HandleFunc("POST "+base+"/{app_token}/tables", s.handleCreateTable)
+	mux.HandleFunc("GET "+base+"/{app_token}/tables", s.handleListTables)
+	mux.HandleFunc("DELETE "+base+"/{app_token}/tables/{table_id}", s.handleDeleteTable)
+
+	const fields = base + "/{app_token}/tables/{table_id}/fields"
+	mux.HandleFunc("GET "+fields, s.handleListFields)
+	mux.HandleFunc("POST "+fields, s.handleCreateField)
+	mux.HandleFunc("PUT "+fields+"/{field_id}", s.handleUpdateField)
+	mux.HandleFunc("DELETE "+fields+"/{field_id}", s.handleDeleteField)
+
+	const views = base + "/{app_token}/tables/{table_id}/views"
+	mux.HandleFunc("GET "+views, s.handleListViews)
+	mux.HandleFunc("POST "+views, s.handleCreateView)
+	mux.HandleFunc("PATCH "+views+"/{view_id}", s.handlePatchView)
+	mux.HandleFunc("DELETE "+views+"/{view_id}", s.handleDeleteView)
+
+	const records = base + "/{app_token}/tables/{table_id}/records"
+	mux.HandleFunc("POST "+records, s.handleCreateRecord)
+	mux.HandleFunc("GET "+records, s.handleListRecords)
+	mux.HandleFunc("GET "+records+"/{record_id}", s.handleGetRecord)
+	mux.HandleFunc("PUT "+records+"/{record_id}", s.handleUpdateRecord)
+	mux.HandleFunc("DELETE "+records+"/{record_id}", s.handleDeleteRecord)
+	mux.HandleFunc("POST "+records+"/batch_create", s.handleBatchCreateRecords)
+	mux.HandleFunc("POST "+records+"/batch_update", s.handleBatchUpdateRecords)
+	mux.HandleFunc("POST "+records+"/batch_delete", s.handleBatchDeleteRecords)
+	mux.HandleFunc("POST "+records+"/search", s.handleSearchRecords)
+}
+
+// Records 返回数据表中所有记录的字段（按创建顺序），便于测试断言
+func (s *Server) Records(appToken, tableID string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.table(appToken, tableID)
+	if err != nil {
+		return nil
+	}
+	out := make([]map[string]interface{}, 0, len(t.records))
+	for _, rec := range t.records {
+		out = append(out, copyFields(rec.fields))
+	}
+	return out
+}
+
+// table 查找数据表，调用方需持有锁
+func (s *Server) table(appToken, tableID string) (*table, *apiError) {
+	a, ok := s.apps[appToken]
+	if !ok {
+		return nil, errorf(http.StatusNotFound, codeAppNotFound, "BaseTokenNotFound")
+	}
+	for _, t := range a.tables {
+		if t.id == tableID {
+			return t, nil
+		}
+	}
+	return nil, errorf(http.StatusNotFound, codeTableNotFound, "TableIdNotFound")
+}
+
+// requestTable 根据路径参数查找数据表，调用方需持有锁
+func (s *Server) requestTable(r *http.Request) (*table, *apiError) {
+	return s.table(r.PathValue("app_token"), r.PathValue("table_id"))
+}
+
+func (t *table) record(recordID string) (int, *record, *apiError) {
+	for i, rec := range t.records {
+		if rec.id == recordID {
+			return i, rec, nil
+		}
+	}
+	return -1, nil, errorf(http.StatusNotFound, codeRecordNotFound, "RecordIdNotFound")
+}
+
+func (t *table) field(name string) *field {
+	for _, f := range t.fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// normalize 校验字段名和字段值，并转换为读取时的格式（如文本转为富文本片段）。
+// 与飞书一致，空值（空文本、空数组、未勾选的复选框）转换为 nil，读取时不返回。
+func (t *table) normalize(fields map[string]interface{}) (map[string]interface{}, *apiError) {
+	out := make(map[string]interface{}, len(fields))
+	for name, value := range fields {
+		f := t.field(name)
+		if f == nil {
+			return nil, errorf(http.StatusBadRequest, codeFieldNotFound, "FieldNameNotFound: %s", name)
+		}
+		if f.isEmpty(value) {
+			out[name] = nil
+			continue
+		}
+		switch f.Type {
+		case fieldTypeText:
+			if text, ok := value.(string); ok {
+				value = []interface{}{map[string]interface{}{"type": "text", "text": text}}
+			}
+		case fieldTypeNumber:
+			if _, ok := value.(float64); !ok {
+				return nil, errorf(http.StatusBadRequest, codeNumberConvFail, "NumberFieldConvFail: %s", name)
+			}
+		case fieldTypeCheckbox:
+			if _, ok := value.(bool); !ok {
+				return nil, errorf(http.StatusBadRequest, codeCheckConvFail, "CheckboxFieldConvFail: %s", name)
+			}
+		}
+		out[name] = value
+	}
+	return out, nil
+}
+
+// isEmpty 判断写入该字段的值是否为空，类型不匹配的值交由 normalize 报错
+func (f *field) isEmpty(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v && f.Type == fieldTypeCheckbox
+	case string:
+		return v == "" && f.Type != fieldTypeNumber && f.Type != fieldTypeCheckbox
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// newTable 创建数据表及一个表格视图，未指定字段时与飞书一致创建一个默认的文本字段，调用方需持有锁
+func (s *Server) newTable(name, viewName string, fields []*field) *table {
+	if viewName == "" {
+		viewName = defaultViewName
+	}
+	t := &table{
+		id:           s.nextID("tbl"),
+		name:         name,
+		views:        []*view{{ID: s.nextID("vew"), Name: viewName, Type: viewTypeGrid}},
+		clientTokens: make(map[string][]*record),
+		fieldTokens:  make(map[string]*field),
+	}
+	if len(fields) == 0 {
+		fields = []*field{{Name: "多行文本", Type: fieldTypeText}}
+	}
+	for i, f := range fields {
+		f.ID = s.nextID("fld")
+		f.Primary = i == 0
+		s.assignOptionIDs(f)
+		t.fields = append(t.fields, f)
+	}
+	return t
+}
+
+func (s *Server) handleCreateApp(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name        string `json:"name"`
+		FolderToken string `json:"folder_token"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a := &app{
+		token:       s.nextID("bascn"),
+		name:        body.Name,
+		folderToken: body.FolderToken,
+	}
+	t := s.newTable("数据表", "", nil)
+	a.tables = append(a.tables, t)
+	s.apps[a.token] = a
+
+	writeData(w, map[string]interface{}{
+		"app": map[string]interface{}{
+			"app_token":        a.token,
+			"name":             a.name,
+			"folder_token":     a.folderToken,
+			"url":              s.URL + "/base/" + a.token,
+			"default_table_id": t.id,
+		},
+	})
+}
+
+func (s *Server) handleCreateTable(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Table struct {
+			Name            string      `json:"name"`
+			DefaultViewName string      `json:"default_view_name"`
+			Fields          []fieldBody `json:"fields"`
+		} `json:"table"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.apps[r.PathValue("app_token")]
+	if !ok {
+		writeResult(w, nil, errorf(http.StatusNotFound, codeAppNotFound, "BaseTokenNotFound"))
+		return
+	}
+	fields := make([]*field, 0, len(body.Table.Fields))
+	for _, b := range body.Table.Fields {
+		f := &field{}
+		b.apply(f)
+		fields = append(fields, f)
+	}
+	t := s.newTable(body.Table.Name, body.Table.DefaultViewName, fields)
+	a.tables = append(a.tables, t)
+
+	fieldIDs := make([]string, 0, len(t.fields))
+	for _, f := range t.fields {
+		fieldIDs = append(fieldIDs, f.ID)
+	}
+	writeData(w, map[string]interface{}{
+		"table_id":        t.id,
+		"default_view_id": t.views[0].ID,
+		"field_id_list":   fieldIDs,
+	})
+}
+
+func (s *Server) handleListTables(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.apps[r.PathValue("app_token")]
+	if !ok {
+		writeResult(w, nil, errorf(http.StatusNotFound, codeAppNotFound, "BaseTokenNotFound"))
+		return
+	}
+
+	start, end, next, hasMore, err := page(r, len(a.tables))
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, end-start)
+	for _, t := range a.tables[start:end] {
+		items = append(items, map[string]interface{}{
+			"table_id": t.id,
+			"name":     t.name,
+			"revision": 1,
+		})
+	}
+	writeData(w, map[string]interface{}{
+		"items":      items,
+		"page_token": next,
+		"has_more":   hasMore,
+		"total":      len(a.tables),
+	})
+}
+
+// handleDeleteTable 删除数据表，与飞书一致不允许删除最后一张数据表
+func (s *Server) handleDeleteTable(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.apps[r.PathValue("app_token")]
+	if !ok {
+		writeResult(w, nil, errorf(http.StatusNotFound, codeAppNotFound, "BaseTokenNotFound"))
+		return
+	}
+	i := slices.IndexFunc(a.tables, func(t *table) bool { return t.id == r.PathValue("table_id") })
+	if i < 0 {
+		writeResult(w, nil, errorf(http.StatusNotFound, codeTableNotFound, "TableIdNotFound"))
+		return
+	}
+	if len(a.tables) == 1 {
+		writeResult(w, nil, errorf(http.StatusBadRequest, codeWrongRequest, "LastTableCannotDelete"))
+		return
+	}
+	a.tables = slices.Delete(a.tables, i, i+1)
+	writeData(w, map[string]interface{}{})
+}
+
+// createRecords 新增记录，clientToken 不为空且已使用过时返回之前创建的记录，调用方需持有锁
+func (s *Server) createRecords(t *table, clientToken string, fieldsList []map[string]interface{}) ([]*record, *apiError) {
+	if created, ok := t.clientTokens[clientToken]; ok && clientToken != "" {
+		return created, nil
+	}
+
+	normalized := make([]map[string]interface{}, 0, len(fieldsList))
+	for _, fields := range fieldsList {
+		n, err := t.normalize(fields)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, n)
+	}
+
+	now := nowMillis()
+	created := make([]*record, 0, len(normalized))
+	for _, fields := range normalized {
+		for name, value := range fields {
+			if value == nil {
+				delete(fields, name)
+			}
+		}
+		rec := &record{
+			id:               s.nextID("rec"),
+			fields:           fields,
+			createdTime:      now,
+			lastModifiedTime: now,
+		}
+		t.records = append(t.records, rec)
+		created = append(created, rec)
+	}
+	if clientToken != "" {
+		t.clientTokens[clientToken] = created
+	}
+	return created, nil
+}
+
+// updateRecord 更新记录中的部分字段，调用方需持有锁
+func updateRecord(t *table, recordID string, fields map[string]interface{}) (*record, *apiError) {
+	_, rec, err := t.record(recordID)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := t.normalize(fields)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range normalized {
+		if v == nil {
+			delete(rec.fields, k)
+		} else {
+			rec.fields[k] = v
+		}
+	}
+	rec.lastModifiedTime = nowMillis()
+	return rec, nil
+}
+
+type recordBody struct {
+	RecordID string                 `json:"record_id"`
+	Fields   map[string]interface{} `json:"fields"`
+}
+
+func (s *Server) handleCreateRecord(w http.ResponseWriter, r *http.Request) {
+	var body recordBody
+	if err := decodeBody(r, &body); err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	created, err := s.createRecords(t, r.URL.Query().Get("client_token"), []map[string]interface{}{body.Fields})
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	writeData(w, map[string]interface{}{"record": created[0].json(false)})
+}
+
+func (s *Server) handleBatchCreateRecords(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Records []recordBody `json:"records"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	if len(body.Records) > maxPageSize {
+		writeResult(w, nil, errorf(http.StatusBadRequest, codeTooManyRecords, "TooManyRecords: at most %d records per request", maxPageSize))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	fieldsList := make([]map[string]interface{}, 0, len(body.Records))
+	for _, rec := range body.Records {
+		fieldsList = append(fieldsList, rec.Fields)
+	}
+	created, err := s.createRecords(t, r.URL.Query().Get("client_token"), fieldsList)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, len(created))
+	for _, rec := range created {
+		items = append(items, rec.json(false))
+	}
+	writeData(w, map[string]interface{}{"records": items})
+}
+
+func (s *Server) handleGetRecord(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	_, rec, err := t.record(r.PathValue("record_id"))
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	automatic := r.URL.Query().Get("automatic_fields") == "true"
+	writeData(w, map[string]interface{}{"record": rec.json(automatic)})
+}
+
+func (s *Server) handleListRecords(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	start, end, next, hasMore, err := page(r, len(t.records))
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	automatic := r.URL.Query().Get("automatic_fields") == "true"
+	items := make([]map[string]interface{}, 0, end-start)
+	for _, rec := range t.records[start:end] {
+		items = append(items, rec.json(automatic))
+	}
+	writeData(w, map[string]interface{}{
+		"items":      items,
+		"page_token": next,
+		"has_more":   hasMore,
+		"total":      len(t.records),
+	})
+}
+
+func (s *Server) handleUpdateRecord(w http.ResponseWriter, r *http.Request) {
+	var body recordBody
+	if err := decodeBody(r, &body); err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	rec, err := updateRecord(t, r.PathValue("record_id"), body.Fields)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	writeData(w, map[string]interface{}{"record": rec.json(false)})
+}
+
+func (s *Server) handleBatchUpdateRecords(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Records []recordBody `json:"records"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	if len(body.Records) > maxPageSize {
+		writeResult(w, nil, errorf(http.StatusBadRequest, codeTooManyRecords, "TooManyRecords: at most %d records per request", maxPageSize))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	// 先整体校验，与飞书一致：任意一条失败则整批不生效
+	for _, rec := range body.Records {
+		if _, _, err := t.record(rec.RecordID); err != nil {
+			writeResult(w, nil, err)
+			return
+		}
+		if _, err := t.normalize(rec.Fields); err != nil {
+			writeResult(w, nil, err)
+			return
+		}
+	}
+	items := make([]map[string]interface{}, 0, len(body.Records))
+	for _, rec := range body.Records {
+		updated, _ := updateRecord(t, rec.RecordID, rec.Fields)
+		items = append(items, updated.json(false))
+	}
+	writeData(w, map[string]interface{}{"records": items})
+}
+
+func (s *Server) handleDeleteRecord(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	recordID := r.PathValue("record_id")
+	i, _, err := t.record(recordID)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	t.records = slices.Delete(t.records, i, i+1)
+	writeData(w, map[string]interface{}{"deleted": true, "record_id": recordID})
+}
+
+func (s *Server) handleBatchDeleteRecords(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Records []string `json:"records"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	if len(body.Records) > maxPageSize {
+		writeResult(w, nil, errorf(http.StatusBadRequest, codeTooManyRecords, "TooManyRecords: at most %d records per request", maxPageSize))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	for _, recordID := range body.Records {
+		if _, _, err := t.record(recordID); err != nil {
+			writeResult(w, nil, err)
+			return
+		}
+	}
+	items := make([]map[string]interface{}, 0, len(body.Records))
+	for _, recordID := range body.Records {
+		if i, _, err := t.record(recordID); err == nil {
+			t.records = slices.Delete(t.records, i, i+1)
+		}
+		items = append(items, map[string]interface{}{"deleted": true, "record_id": recordID})
+	}
+	writeData(w, map[string]interface{}{"records": items})
+}
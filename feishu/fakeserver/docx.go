@@ -0,0 +1,230 @@
+package fakeserver
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// 块类型（与飞书云文档一致）
+const blockTypePage = 1
+
+type document struct {
+	id       string
+	title    string
+	revision int
+	blocks   map[string]map[string]interface{} // block_id -> 块内容（接口格式）
+	children map[string][]string               // block_id -> 子块 ID，按顺序
+}
+
+func (s *Server) registerDocx(mux *http.ServeMux) {
+	const base = "/open-apis/docx/v1/documents"
+	mux.HandleFunc("POST "+base, s.handleCreateDocument)
+	mux.HandleFunc("GET "+base+"/{document_id}", s.handleGetDocument)
+	mux.HandleFunc("GET "+base+"/{document_id}/raw_content", s.handleRawContent)
+	mux.HandleFunc("GET "+base+"/{document_id}/blocks", s.handleListBlocks)
+	mux.HandleFunc("POST "+base+"/{document_id}/blocks/{block_id}/children", s.handleCreateBlockChildren)
+}
+
+// document 根据路径参数查找文档，调用方需持有锁
+func (s *Server) document(r *http.Request) (*document, *apiError) {
+	doc, ok := s.documents[r.PathValue("document_id")]
+	if !ok {
+		return nil, errorf(http.StatusNotFound, codeDocNotFound, "document not found")
+	}
+	return doc, nil
+}
+
+func (doc *document) json() map[string]interface{} {
+	return map[string]interface{}{
+		"document_id": doc.id,
+		"revision_id": doc.revision,
+		"title":       doc.title,
+	}
+}
+
+// block 返回带 children 列表的块内容
+func (doc *document) block(blockID string) map[string]interface{} {
+	out := make(map[string]interface{}, len(doc.blocks[blockID])+1)
+	for k, v := range doc.blocks[blockID] {
+		out[k] = v
+	}
+	if children := doc.children[blockID]; len(children) > 0 {
+		out["children"] = slices.Clone(children)
+	}
+	return out
+}
+
+// walk 按文档顺序（深度优先）返回所有块 ID，第一个为页面块
+func (doc *document) walk() []string {
+	var ids []string
+	var visit func(string)
+	visit = func(id string) {
+		ids = append(ids, id)
+		for _, child := range doc.children[id] {
+			visit(child)
+		}
+	}
+	visit(doc.id)
+	return ids
+}
+
+// blockText 提取块中所有文本元素的内容
+func blockText(block map[string]interface{}) string {
+	var sb strings.Builder
+	for _, v := range block {
+		body, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		elements, _ := body["elements"].([]interface{})
+		for _, e := range elements {
+			element, _ := e.(map[string]interface{})
+			run, _ := element["text_run"].(map[string]interface{})
+			if content, ok := run["content"].(string); ok {
+				sb.WriteString(content)
+			}
+		}
+	}
+	return sb.String()
+}
+
+func (s *Server) handleCreateDocument(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Title       string `json:"title"`
+		FolderToken string `json:"folder_token"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc := &document{
+		id:       s.nextID("doxcn"),
+		title:    body.Title,
+		revision: 1,
+		children: make(map[string][]string),
+	}
+	// 与飞书一致，页面块的 block_id 等于 document_id
+	doc.blocks = map[string]map[string]interface{}{
+		doc.id: {
+			"block_id":   doc.id,
+			"block_type": blockTypePage,
+			"page": map[string]interface{}{
+				"elements": []interface{}{
+					map[string]interface{}{"text_run": map[string]interface{}{"content": body.Title}},
+				},
+			},
+		},
+	}
+	s.documents[doc.id] = doc
+	writeData(w, map[string]interface{}{"document": doc.json()})
+}
+
+func (s *Server) handleGetDocument(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc, err := s.document(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	writeData(w, map[string]interface{}{"document": doc.json()})
+}
+
+func (s *Server) handleRawContent(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc, err := s.document(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	lines := make([]string, 0, len(doc.blocks))
+	for _, id := range doc.walk() {
+		lines = append(lines, blockText(doc.blocks[id]))
+	}
+	writeData(w, map[string]interface{}{"content": strings.Join(lines, "\n") + "\n"})
+}
+
+func (s *Server) handleListBlocks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc, err := s.document(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	ids := doc.walk()
+	start, end, next, hasMore, err := page(r, len(ids))
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, end-start)
+	for _, id := range ids[start:end] {
+		items = append(items, doc.block(id))
+	}
+	writeData(w, map[string]interface{}{
+		"items":      items,
+		"page_token": next,
+		"has_more":   hasMore,
+	})
+}
+
+func (s *Server) handleCreateBlockChildren(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Children []map[string]interface{} `json:"children"`
+		Index    *int                     `json:"index"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc, err := s.document(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	parentID := r.PathValue("block_id")
+	if _, ok := doc.blocks[parentID]; !ok {
+		writeResult(w, nil, errorf(http.StatusNotFound, codeBlockNotFound, "block not found"))
+		return
+	}
+
+	siblings := doc.children[parentID]
+	index := len(siblings)
+	if body.Index != nil && *body.Index >= 0 && *body.Index < len(siblings) {
+		index = *body.Index
+	}
+
+	created := make([]map[string]interface{}, 0, len(body.Children))
+	ids := make([]string, 0, len(body.Children))
+	for _, child := range body.Children {
+		block := make(map[string]interface{}, len(child)+2)
+		for k, v := range child {
+			if k == "children" {
+				continue
+			}
+			block[k] = v
+		}
+		id := s.nextID("doxcnBlk")
+		block["block_id"] = id
+		block["parent_id"] = parentID
+		doc.blocks[id] = block
+		ids = append(ids, id)
+		created = append(created, doc.block(id))
+	}
+	doc.children[parentID] = slices.Insert(siblings, index, ids...)
+	doc.revision++
+
+	writeData(w, map[string]interface{}{
+		"children":             created,
+		"document_revision_id": doc.revision,
+	})
+}
@@ -0,0 +1,197 @@
+package fakeserver
+
+import "net/http"
+
+// assignOptionIDs 为单选、多选字段中没有 ID 的选项分配 ID，调用方需持有锁
+func (s *Server) assignOptionIDs(f *field) {
+	options, _ := f.Property["options"].([]interface{})
+	for _, item := range options {
+		if option, ok := item.(map[string]interface{}); ok && option["id"] == nil {
+			option["id"] = s.nextID("opt")
+		}
+	}
+}
+
+func (s *Server) handleListFields(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	start, end, next, hasMore, err := page(r, len(t.fields))
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	writeData(w, map[string]interface{}{
+		"items":      t.fields[start:end],
+		"page_token": next,
+		"has_more":   hasMore,
+		"total":      len(t.fields),
+	})
+}
+
+// fieldBody 创建、更新字段的请求体
+type fieldBody struct {
+	Name        string                 `json:"field_name"`
+	Type        int                    `json:"type"`
+	UIType      string                 `json:"ui_type"`
+	Property    map[string]interface{} `json:"property"`
+	Description *struct {
+		Text string `json:"text"`
+	} `json:"description"`
+}
+
+// validate 校验字段名和类型，fieldID 为更新时的字段 ID，调用方需持有锁
+func (b *fieldBody) validate(t *table, fieldID string) *apiError {
+	if b.Name == "" || b.Type == 0 {
+		return errorf(http.StatusBadRequest, codeWrongRequest, "WrongRequestBody: field_name and type are required")
+	}
+	if f := t.field(b.Name); f != nil && f.ID != fieldID {
+		return errorf(http.StatusBadRequest, codeWrongRequest, "FieldNameDuplicated: %s", b.Name)
+	}
+	return nil
+}
+
+// apply 将请求体写入字段
+func (b *fieldBody) apply(f *field) {
+	f.Name = b.Name
+	f.Type = b.Type
+	f.UIType = b.UIType
+	f.Property = b.Property
+	f.Description = ""
+	if b.Description != nil {
+		f.Description = b.Description.Text
+	}
+}
+
+// fieldJSON 转换为创建、更新字段接口的返回格式，其中描述为对象
+func fieldJSON(f *field) map[string]interface{} {
+	out := map[string]interface{}{
+		"field_id":   f.ID,
+		"field_name": f.Name,
+		"type":       f.Type,
+		"is_primary": f.Primary,
+	}
+	if f.UIType != "" {
+		out["ui_type"] = f.UIType
+	}
+	if f.Property != nil {
+		out["property"] = f.Property
+	}
+	if f.Description != "" {
+		out["description"] = map[string]interface{}{"text": f.Description}
+	}
+	return out
+}
+
+// fieldByID 按 ID 查找字段，调用方需持有锁
+func (t *table) fieldByID(fieldID string) (int, *field, *apiError) {
+	for i, f := range t.fields {
+		if f.ID == fieldID {
+			return i, f, nil
+		}
+	}
+	return -1, nil, errorf(http.StatusNotFound, codeFieldIDNotFound, "FieldIdNotFound")
+}
+
+func (s *Server) handleCreateField(w http.ResponseWriter, r *http.Request) {
+	var body fieldBody
+	if err := decodeBody(r, &body); err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	clientToken := r.URL.Query().Get("client_token")
+	if f, ok := t.fieldTokens[clientToken]; ok && clientToken != "" {
+		writeData(w, map[string]interface{}{"field": fieldJSON(f)})
+		return
+	}
+	if err := body.validate(t, ""); err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	f := &field{ID: s.nextID("fld")}
+	body.apply(f)
+	s.assignOptionIDs(f)
+	t.fields = append(t.fields, f)
+	if clientToken != "" {
+		t.fieldTokens[clientToken] = f
+	}
+	writeData(w, map[string]interface{}{"field": fieldJSON(f)})
+}
+
+// handleUpdateField 整体替换字段定义，改名时同步修改记录中的字段名；修改类型时保留原有的值
+func (s *Server) handleUpdateField(w http.ResponseWriter, r *http.Request) {
+	var body fieldBody
+	if err := decodeBody(r, &body); err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	_, f, err := t.fieldByID(r.PathValue("field_id"))
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	if err := body.validate(t, f.ID); err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	if body.Name != f.Name {
+		for _, rec := range t.records {
+			if v, ok := rec.fields[f.Name]; ok {
+				delete(rec.fields, f.Name)
+				rec.fields[body.Name] = v
+			}
+		}
+	}
+	body.apply(f)
+	s.assignOptionIDs(f)
+	writeData(w, map[string]interface{}{"field": fieldJSON(f)})
+}
+
+// handleDeleteField 删除字段及记录中该字段的值，与飞书一致不允许删除索引列
+func (s *Server) handleDeleteField(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	i, f, err := t.fieldByID(r.PathValue("field_id"))
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	if f.Primary {
+		writeResult(w, nil, errorf(http.StatusBadRequest, codeWrongRequest, "PrimaryFieldCannotDelete"))
+		return
+	}
+
+	t.fields = append(t.fields[:i], t.fields[i+1:]...)
+	for _, rec := range t.records {
+		delete(rec.fields, f.Name)
+	}
+	writeData(w, map[string]interface{}{"field_id": f.ID, "deleted": true})
+}
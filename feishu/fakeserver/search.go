@@ -0,0 +1,311 @@
+package fakeserver
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// 模拟服务返回的查询相关错误码
+const (
+	codeInvalidSort   = 1254017
+	codeInvalidFilter = 1254018
+	codeViewNotFound  = 1254042
+)
+
+type searchBody struct {
+	ViewID     string   `json:"view_id"`
+	FieldNames []string `json:"field_names"`
+	Sort       []struct {
+		FieldName string `json:"field_name"`
+		Desc      bool   `json:"desc"`
+	} `json:"sort"`
+	Filter          *filterInfo `json:"filter"`
+	AutomaticFields bool        `json:"automatic_fields"`
+}
+
+type filterInfo struct {
+	Conjunction string        `json:"conjunction"`
+	Conditions  []condition   `json:"conditions"`
+	Children    []*filterInfo `json:"children"`
+}
+
+type condition struct {
+	FieldName string   `json:"field_name"`
+	Operator  string   `json:"operator"`
+	Value     []string `json:"value"`
+}
+
+// handleSearchRecords 查询记录，支持常用的筛选运算符、排序和返回字段。
+// 与飞书相比做了简化：日期只支持 ExactDate 且按毫秒时间戳比较，视图只支持数据表的默认视图。
+func (s *Server) handleSearchRecords(w http.ResponseWriter, r *http.Request) {
+	var body searchBody
+	if r.ContentLength != 0 {
+		if err := decodeBody(r, &body); err != nil {
+			writeResult(w, nil, err)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	if body.ViewID != "" {
+		if _, _, err := t.view(body.ViewID); err != nil {
+			writeResult(w, nil, err)
+			return
+		}
+	}
+	for _, name := range body.FieldNames {
+		if t.field(name) == nil {
+			writeResult(w, nil, errorf(http.StatusBadRequest, codeFieldNotFound, "FieldNameNotFound: %s", name))
+			return
+		}
+	}
+	for _, sort := range body.Sort {
+		if t.field(sort.FieldName) == nil {
+			writeResult(w, nil, errorf(http.StatusBadRequest, codeInvalidSort, "InvalidSort: field %s not found", sort.FieldName))
+			return
+		}
+	}
+
+	matched := make([]*record, 0, len(t.records))
+	for _, rec := range t.records {
+		ok, err := t.match(body.Filter, rec, true)
+		if err != nil {
+			writeResult(w, nil, err)
+			return
+		}
+		if ok {
+			matched = append(matched, rec)
+		}
+	}
+	if len(body.Sort) > 0 {
+		slices.SortStableFunc(matched, func(a, b *record) int {
+			for _, sort := range body.Sort {
+				c := compareValues(a.fields[sort.FieldName], b.fields[sort.FieldName])
+				if sort.Desc {
+					c = -c
+				}
+				if c != 0 {
+					return c
+				}
+			}
+			return 0
+		})
+	}
+
+	start, end, next, hasMore, err := page(r, len(matched))
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, end-start)
+	for _, rec := range matched[start:end] {
+		item := rec.json(body.AutomaticFields)
+		if len(body.FieldNames) > 0 {
+			fields := make(map[string]interface{}, len(body.FieldNames))
+			for _, name := range body.FieldNames {
+				if v, ok := rec.fields[name]; ok {
+					fields[name] = v
+				}
+			}
+			item["fields"] = fields
+		}
+		items = append(items, item)
+	}
+	writeData(w, map[string]interface{}{
+		"items":      items,
+		"page_token": next,
+		"has_more":   hasMore,
+		"total":      len(matched),
+	})
+}
+
+// match 判断记录是否满足筛选条件，top 表示顶层条件组（只有顶层条件组可以包含子条件组）
+func (t *table) match(f *filterInfo, rec *record, top bool) (bool, *apiError) {
+	if f == nil || (len(f.Conditions) == 0 && len(f.Children) == 0) {
+		return true, nil
+	}
+	if !top && len(f.Children) > 0 {
+		return false, errorf(http.StatusBadRequest, codeInvalidFilter, "InvalidFilter: children cannot be nested")
+	}
+	var and bool
+	switch f.Conjunction {
+	case "and":
+		and = true
+	case "or":
+	default:
+		return false, errorf(http.StatusBadRequest, codeInvalidFilter, "InvalidFilter: invalid conjunction %q", f.Conjunction)
+	}
+
+	results := make([]bool, 0, len(f.Conditions)+len(f.Children))
+	for _, cond := range f.Conditions {
+		ok, err := t.matchCondition(cond, rec)
+		if err != nil {
+			return false, err
+		}
+		results = append(results, ok)
+	}
+	for _, child := range f.Children {
+		ok, err := t.match(child, rec, false)
+		if err != nil {
+			return false, err
+		}
+		results = append(results, ok)
+	}
+
+	if and {
+		return !slices.Contains(results, false), nil
+	}
+	return slices.Contains(results, true), nil
+}
+
+// matchCondition 判断记录是否满足单个条件
+func (t *table) matchCondition(cond condition, rec *record) (bool, *apiError) {
+	if t.field(cond.FieldName) == nil {
+		return false, errorf(http.StatusBadRequest, codeInvalidFilter, "InvalidFilter: field %s not found", cond.FieldName)
+	}
+	value := rec.fields[cond.FieldName]
+
+	switch cond.Operator {
+	case "isEmpty":
+		return isEmpty(value), nil
+	case "isNotEmpty":
+		return !isEmpty(value), nil
+	}
+
+	target, err := conditionTarget(cond)
+	if err != nil {
+		return false, err
+	}
+	switch cond.Operator {
+	case "is":
+		return compareValues(value, target) == 0 && !isEmpty(value), nil
+	case "isNot":
+		return isEmpty(value) || compareValues(value, target) != 0, nil
+	case "contains":
+		return containsAny(value, cond.Value), nil
+	case "doesNotContain":
+		return !containsAny(value, cond.Value), nil
+	case "isGreater":
+		return !isEmpty(value) && compareValues(value, target) > 0, nil
+	case "isGreaterEqual":
+		return !isEmpty(value) && compareValues(value, target) >= 0, nil
+	case "isLess":
+		return !isEmpty(value) && compareValues(value, target) < 0, nil
+	case "isLessEqual":
+		return !isEmpty(value) && compareValues(value, target) <= 0, nil
+	}
+	return false, errorf(http.StatusBadRequest, codeInvalidFilter, "InvalidFilter: unsupported operator %q", cond.Operator)
+}
+
+// conditionTarget 取出条件的比较值，日期条件 ["ExactDate", 毫秒时间戳] 转换为数字
+func conditionTarget(cond condition) (interface{}, *apiError) {
+	switch {
+	case len(cond.Value) == 0:
+		return nil, errorf(http.StatusBadRequest, codeInvalidFilter, "InvalidFilter: operator %s requires a value", cond.Operator)
+	case cond.Value[0] == "ExactDate":
+		if len(cond.Value) != 2 {
+			return nil, errorf(http.StatusBadRequest, codeInvalidFilter, "InvalidFilter: ExactDate requires a timestamp")
+		}
+		ms, err := strconv.ParseFloat(cond.Value[1], 64)
+		if err != nil {
+			return nil, errorf(http.StatusBadRequest, codeInvalidFilter, "InvalidFilter: invalid timestamp %q", cond.Value[1])
+		}
+		return ms, nil
+	}
+	return cond.Value[0], nil
+}
+
+// isEmpty 判断字段值是否为空
+func isEmpty(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// containsAny 判断字段的文本中是否包含 values 中的任意一个
+func containsAny(v interface{}, values []string) bool {
+	text := valueText(v)
+	for _, value := range values {
+		if strings.Contains(text, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// valueText 将字段值转换为文本：富文本片段拼接其中的文字，多选等数组用逗号连接
+func valueText(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]interface{}:
+		if text, ok := v["text"].(string); ok {
+			return text
+		}
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		segments := true
+		for _, item := range v {
+			if m, ok := item.(map[string]interface{}); !ok || m["type"] == nil {
+				segments = false
+			}
+			parts = append(parts, valueText(item))
+		}
+		if segments {
+			return strings.Join(parts, "")
+		}
+		return strings.Join(parts, ",")
+	}
+	return ""
+}
+
+// compareValues 比较字段值：双方都能转换为数字时按数字比较，否则按文本比较。空值排在最前。
+func compareValues(a, b interface{}) int {
+	switch {
+	case isEmpty(a) && isEmpty(b):
+		return 0
+	case isEmpty(a):
+		return -1
+	case isEmpty(b):
+		return 1
+	}
+	x, xok := numberValue(a)
+	y, yok := numberValue(b)
+	if xok && yok {
+		return cmp.Compare(x, y)
+	}
+	return strings.Compare(valueText(a), valueText(b))
+}
+
+// numberValue 将数字、日期（毫秒时间戳）以及数字文本转换为 float64
+func numberValue(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.ParseFloat(v, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,342 @@
+// Package fakeserver 提供一个基于 httptest 的内存版飞书开放平台，
+// 实现了租户 token、多维表格（应用、数据表、字段、视图、记录及记录查询）、云文档（文档、块）和知识库节点查询的常用接口，
+// 用于在没有真实凭证的情况下离线测试 feishu 包以及依赖它的代码。
+//
+// 典型用法：
+//
+//	srv := fakeserver.New()
+//	defer srv.Close()
+//	client := srv.NewClient()
+//	appToken, tableID, _ := client.CreateAppAndTable("测试", "", "产品列表", nil)
+package fakeserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"feishu_bitable_demo/feishu"
+)
+
+// FakeTenantAccessToken 模拟服务签发的租户 token。
+// SDK 会在进程内按 app_id 缓存 token，因此所有模拟服务使用同一个固定值。
+const FakeTenantAccessToken = "t-fakeserver-tenant-access-token"
+
+// 模拟服务中所有写操作的操作人
+const (
+	FakeUserID   = "ou_fakeserver_user"
+	FakeUserName = "模拟用户"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 500
+)
+
+// 模拟服务返回的错误码，与飞书开放平台一致
+const (
+	codeInvalidToken     = 99991663
+	codeWrongRequest     = 1254001
+	codeAppNotFound      = 1254040
+	codeTableNotFound    = 1254041
+	codeRecordNotFound   = 1254043
+	codeFieldIDNotFound  = 1254044
+	codeFieldNotFound    = 1254045
+	codeNumberConvFail   = 1254061
+	codeCheckConvFail    = 1254065
+	codeTooManyRecords   = 1254104
+	codeWikiNodeNotFound = 131005
+	codeDocNotFound      = 1770002
+	codeBlockNotFound    = 1770003
+)
+
+// Server 内存版飞书开放平台
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	seq       int
+	apps      map[string]*app
+	documents map[string]*document
+	wikiNodes map[string]*wikiNode
+	requests  []Request
+	latency   time.Duration
+	failures  []failure
+}
+
+// Request 模拟服务收到的一个业务请求，用于断言客户端发送的内容
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// failure 预设的错误响应
+type failure struct {
+	status int
+	code   int
+	msg    string
+	header http.Header
+	after  bool   // 先正常处理请求再返回错误，模拟服务端已写入但响应丢失
+	method string // 不为空时只对该方法且路径以 path 结尾的请求生效
+	path   string
+}
+
+// matches 判断预设的错误是否作用于请求 r
+func (f *failure) matches(r *http.Request) bool {
+	return f.method == "" || (r.Method == f.method && strings.HasSuffix(r.URL.Path, f.path))
+}
+
+// New 启动一个新的模拟服务，使用完毕后需调用 Close
+func New() *Server {
+	s := &Server{
+		apps:      make(map[string]*app),
+		documents: make(map[string]*document),
+		wikiNodes: make(map[string]*wikiNode),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /open-apis/auth/v3/tenant_access_token/internal", s.handleTenantAccessToken)
+	mux.HandleFunc("POST /open-apis/auth/v3/app_access_token/internal", s.handleTenantAccessToken)
+	s.registerBitable(mux)
+	s.registerDocx(mux)
+	s.registerWiki(mux)
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+	return s
+}
+
+// NewClient 创建一个指向该模拟服务的客户端，默认关闭限流以加快测试
+func (s *Server) NewClient(opts ...feishu.Option) *feishu.MultiTableClient {
+	options := append([]feishu.Option{
+		feishu.WithBaseURL(s.URL),
+		feishu.WithoutRateLimit(),
+	}, opts...)
+	return feishu.NewMultiTableClient("cli_fakeserver", "fakeserver_secret", options...)
+}
+
+// FailNext 让接下来的 n 个业务请求返回指定的错误响应，用于测试重试和错误处理。
+// header 可用于模拟限流响应头（如 x-ogw-ratelimit-reset），可以为 nil。
+func (s *Server) FailNext(n, status, code int, msg string, header http.Header) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, failure{status: status, code: code, msg: msg, header: header})
+	}
+}
+
+// FailNextAfterProcessing 让接下来的 n 个业务请求正常处理（写入数据）后丢弃响应，改为返回指定的错误，
+// 用于模拟超时或网关错误时请求实际已生效的情况，测试重试的幂等性。
+func (s *Server) FailNextAfterProcessing(n, status, code int, msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, failure{status: status, code: code, msg: msg, after: true})
+	}
+}
+
+// FailNextOn 与 FailNext 相同，但只对方法为 method 且路径以 path 结尾的请求生效（如 "POST", "records/batch_update"），
+// 用于让一次调用中的某个特定请求失败，其余请求照常处理。
+func (s *Server) FailNextOn(method, path string, n, status, code int, msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, failure{status: status, code: code, msg: msg, method: method, path: path})
+	}
+}
+
+// SetLatency 为每个业务请求增加固定延迟，用于测试超时和取消
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// RequestCount 返回已处理的业务请求数（不含获取 token 的请求）
+func (s *Server) RequestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+// Requests 返回已收到的业务请求（不含获取 token 的请求），按收到的顺序排列
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// middleware 校验 token，并处理预设的延迟和错误
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Tt-Logid", fmt.Sprintf("fakeserver-%d", time.Now().UnixNano()))
+		if strings.HasPrefix(r.URL.Path, "/open-apis/auth/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+FakeTenantAccessToken {
+			writeError(w, http.StatusUnauthorized, codeInvalidToken, "Invalid access token for authorization")
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, codeWrongRequest, "read body: "+err.Error())
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Body: body})
+		latency := s.latency
+		var fail *failure
+		for i := range s.failures {
+			if s.failures[i].matches(r) {
+				f := s.failures[i]
+				fail = &f
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+				break
+			}
+		}
+		s.mu.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(latency):
+			}
+		}
+
+		if fail != nil {
+			if fail.after {
+				next.ServeHTTP(httptest.NewRecorder(), r)
+			}
+			for k, vs := range fail.header {
+				for _, v := range vs {
+					w.Header().Add(k, v)
+				}
+			}
+			writeError(w, fail.status, fail.code, fail.msg)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleTenantAccessToken(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"code":                0,
+		"msg":                 "ok",
+		"tenant_access_token": FakeTenantAccessToken,
+		"app_access_token":    FakeTenantAccessToken,
+		"expire":              7200,
+	})
+}
+
+// nextID 生成带前缀的唯一 ID，调用方需持有锁
+func (s *Server) nextID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%sFake%08d", prefix, s.seq)
+}
+
+// nowMillis 当前毫秒时间戳，与飞书接口的时间格式一致
+func nowMillis() int64 {
+	return time.Now().UnixMilli()
+}
+
+// apiError 处理请求时产生的业务错误
+type apiError struct {
+	status int
+	code   int
+	msg    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("[code=%d] %s", e.code, e.msg)
+}
+
+func errorf(status, code int, format string, args ...interface{}) *apiError {
+	return &apiError{status: status, code: code, msg: fmt.Sprintf(format, args...)}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeData 写入成功响应
+func writeData(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"code": 0,
+		"msg":  "success",
+		"data": data,
+	})
+}
+
+// writeError 写入错误响应
+func writeError(w http.ResponseWriter, status, code int, msg string) {
+	writeJSON(w, status, map[string]interface{}{
+		"code": code,
+		"msg":  msg,
+		"error": map[string]interface{}{
+			"log_id": w.Header().Get("X-Tt-Logid"),
+		},
+	})
+}
+
+// writeResult 根据 err 写入成功或错误响应
+func writeResult(w http.ResponseWriter, data interface{}, err *apiError) {
+	if err != nil {
+		writeError(w, err.status, err.code, err.msg)
+		return
+	}
+	writeData(w, data)
+}
+
+// decodeBody 解析 JSON 请求体
+func decodeBody(r *http.Request, v interface{}) *apiError {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return errorf(http.StatusBadRequest, codeWrongRequest, "WrongRequestBody: %v", err)
+	}
+	return nil
+}
+
+// page 按 page_size 和 page_token 查询参数计算分页区间
+func page(r *http.Request, total int) (start, end int, nextToken string, hasMore bool, apiErr *apiError) {
+	size := defaultPageSize
+	if v := r.URL.Query().Get("page_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return 0, 0, "", false, errorf(http.StatusBadRequest, codeWrongRequest, "invalid page_size: %s", v)
+		}
+		size = min(n, maxPageSize)
+	}
+
+	if v := r.URL.Query().Get("page_token"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, "", false, errorf(http.StatusBadRequest, codeWrongRequest, "invalid page_token: %s", v)
+		}
+		start = n
+	}
+
+	start = min(start, total)
+	end = min(start+size, total)
+	if end < total {
+		return start, end, strconv.Itoa(end), true, nil
+	}
+	return start, end, "", false, nil
+}
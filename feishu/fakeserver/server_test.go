@@ -0,0 +1,293 @@
+package fakeserver_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"feishu_bitable_demo/feishu"
+	"feishu_bitable_demo/feishu/fakeserver"
+
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
+)
+
+// fastRetry 测试用的重试策略，避免等待默认的退避时间
+var fastRetry = feishu.WithRetryPolicy(feishu.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+
+func newTable(t *testing.T, opts ...feishu.Option) (*fakeserver.Server, *feishu.MultiTableClient, string, string) {
+	t.Helper()
+	srv := fakeserver.New()
+	t.Cleanup(srv.Close)
+	client := srv.NewClient(opts...)
+	appToken, tableID, err := client.CreateAppAndTable("测试", "", "测试表", feishu.Headers(
+		feishu.FieldSpec{Name: "名称", Type: feishu.FieldTypeText},
+		feishu.FieldSpec{Name: "数量", Type: feishu.FieldTypeNumber},
+		feishu.FieldSpec{Name: "状态", Type: feishu.FieldTypeSingleSelect, Property: feishu.SelectOptions("在售", "下架")},
+	))
+	if err != nil {
+		t.Fatalf("CreateAppAndTable: %v", err)
+	}
+	return srv, client, appToken, tableID
+}
+
+func createRecords(t *testing.T, client *feishu.MultiTableClient, appToken, tableID string, n int) {
+	t.Helper()
+	records := make([]feishu.CreateRecordRequest, 0, n)
+	for i := 1; i <= n; i++ {
+		records = append(records, feishu.CreateRecordRequest{Fields: map[string]interface{}{"名称": fmt.Sprintf("记录%d", i), "数量": i}})
+	}
+	if _, err := client.BatchCreateRecords(appToken, tableID, records); err != nil {
+		t.Fatalf("BatchCreateRecords: %v", err)
+	}
+}
+
+func TestRecords(t *testing.T) {
+	srv, client, appToken, tableID := newTable(t)
+
+	created, err := client.CreateRecord(appToken, tableID, map[string]interface{}{"名称": "苹果", "数量": 3, "状态": "在售"})
+	if err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+	if created.ID == "" {
+		t.Fatal("created record has no record_id")
+	}
+
+	if err := client.UpdateRecord(appToken, tableID, created.ID, map[string]interface{}{"数量": 5}); err != nil {
+		t.Fatalf("UpdateRecord: %v", err)
+	}
+	got, err := client.GetRecord(appToken, tableID, created.ID)
+	if err != nil {
+		t.Fatalf("GetRecord: %v", err)
+	}
+	// 与飞书一致，文本字段以富文本数组返回
+	if name, _ := feishu.AsText(got.Fields["名称"]); name != "苹果" || got.Fields["数量"] != 5.0 || got.Fields["状态"] != "在售" {
+		t.Errorf("fields = %v, want 名称=苹果 数量=5 状态=在售", got.Fields)
+	}
+	if got.CreatedBy == nil || got.CreatedBy.ID != fakeserver.FakeUserID || got.LastModifiedTime.Before(got.CreatedTime) {
+		t.Errorf("audit fields = %v %v %v, want created_by %s", got.CreatedBy, got.CreatedTime, got.LastModifiedTime, fakeserver.FakeUserID)
+	}
+	if records := srv.Records(appToken, tableID); len(records) != 1 || records[0]["数量"] != 5.0 {
+		t.Errorf("srv.Records = %v", records)
+	}
+
+	if _, err := client.CreateRecord(appToken, tableID, map[string]interface{}{"数量": "很多"}); !errors.Is(err, feishu.ErrInvalidField) {
+		t.Errorf("CreateRecord with text in number field: err = %v, want ErrInvalidField", err)
+	}
+
+	if err := client.DeleteRecord(appToken, tableID, created.ID); err != nil {
+		t.Fatalf("DeleteRecord: %v", err)
+	}
+	if _, err := client.GetRecord(appToken, tableID, created.ID); !errors.Is(err, feishu.ErrNotFound) {
+		t.Errorf("GetRecord after delete: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestTables(t *testing.T) {
+	_, client, appToken, tableID := newTable(t)
+
+	orders, err := client.CreateTable(appToken, "订单", nil)
+	if err != nil {
+		t.Fatalf("CreateTable: %v", err)
+	}
+	tables, err := client.ListTables(appToken)
+	if err != nil {
+		t.Fatalf("ListTables: %v", err)
+	}
+	var names []string
+	for _, table := range tables {
+		names = append(names, *table.Name)
+	}
+	// 新建多维表格时自动创建一张默认数据表
+	if got, want := strings.Join(names, ","), "数据表,测试表,订单"; got != want {
+		t.Errorf("tables = %s, want %s", got, want)
+	}
+
+	for _, table := range tables {
+		if *table.TableId != tableID {
+			if err := client.DeleteTable(appToken, *table.TableId); err != nil {
+				t.Fatalf("DeleteTable: %v", err)
+			}
+		}
+	}
+	if err := client.DeleteTable(appToken, tableID); err == nil {
+		t.Error("DeleteTable of the last table: want error")
+	}
+	if _, err := client.ListFields(appToken, orders); !errors.Is(err, feishu.ErrNotFound) {
+		t.Errorf("ListFields of a deleted table: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFields(t *testing.T) {
+	_, client, appToken, tableID := newTable(t)
+
+	fields, err := client.ListFields(appToken, tableID)
+	if err != nil {
+		t.Fatalf("ListFields: %v", err)
+	}
+	if len(fields) != 3 || !fields[0].IsPrimary || fields[1].Type != feishu.FieldTypeNumber {
+		t.Fatalf("fields = %+v", fields)
+	}
+	options := fields[2].Property.Options
+	if len(options) != 2 || *options[0].Name != "在售" || options[0].Id == nil {
+		t.Errorf("options = %+v, want 在售/下架 with ids", options)
+	}
+}
+
+func TestDocx(t *testing.T) {
+	_, client, _, _ := newTable(t)
+
+	doc, err := client.CreateDocument("测试文档", "")
+	if err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+	documentID := *doc.Data.Document.DocumentId
+	children := []*larkdocx.Block{feishu.CreateHeading1Block("标题"), feishu.CreateTextBlock("第一段")}
+	if _, err := client.CreateDocumentBlock(documentID, documentID, -1, children); err != nil {
+		t.Fatalf("CreateDocumentBlock: %v", err)
+	}
+
+	raw, err := client.GetDocumentRawContent(documentID)
+	if err != nil {
+		t.Fatalf("GetDocumentRawContent: %v", err)
+	}
+	if content := *raw.Data.Content; !strings.Contains(content, "标题") || !strings.Contains(content, "第一段") {
+		t.Errorf("raw content = %q", content)
+	}
+	blocks, err := client.ListDocumentBlocks(documentID)
+	if err != nil {
+		t.Fatalf("ListDocumentBlocks: %v", err)
+	}
+	if n := len(blocks.Data.Items); n != 3 {
+		t.Errorf("len(blocks) = %d, want 3 (page, heading, text)", n)
+	}
+	if _, err := client.GetDocument("doxcn_missing"); !errors.Is(err, feishu.ErrNotFound) {
+		t.Errorf("GetDocument of a missing document: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	srv, client, appToken, tableID := newTable(t)
+	createRecords(t, client, appToken, tableID, 45)
+
+	// 未指定 page_size 时每页 20 条
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/open-apis/bitable/v1/apps/%s/tables/%s/records", srv.URL, appToken, tableID), nil)
+	req.Header.Set("Authorization", "Bearer "+fakeserver.FakeTenantAccessToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET records: %v", err)
+	}
+	defer resp.Body.Close()
+	var body struct {
+		Data struct {
+			Items   []json.RawMessage `json:"items"`
+			HasMore bool              `json:"has_more"`
+			Total   int               `json:"total"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Data.Items) != 20 || !body.Data.HasMore || body.Data.Total != 45 {
+		t.Errorf("default page = %d items, has_more=%v, total=%d; want 20, true, 45", len(body.Data.Items), body.Data.HasMore, body.Data.Total)
+	}
+
+	var (
+		names     []string
+		pageToken string
+		pages     int
+	)
+	for {
+		records, next, hasMore, err := client.ListRecords(appToken, tableID, 20, pageToken)
+		if err != nil {
+			t.Fatalf("ListRecords: %v", err)
+		}
+		pages++
+		for _, r := range records {
+			name, err := feishu.AsText(r.Fields["名称"])
+			if err != nil {
+				t.Fatalf("AsText: %v", err)
+			}
+			names = append(names, name)
+		}
+		if !hasMore {
+			break
+		}
+		pageToken = next
+	}
+	if pages != 3 || len(names) != 45 || names[0] != "记录1" || names[44] != "记录45" {
+		t.Errorf("ListRecords: %d pages, %d records (%s…%s), want 3 pages of 45 records in order", pages, len(names), names[0], names[len(names)-1])
+	}
+
+	all, err := client.ListAllRecords(appToken, tableID, 7)
+	if err != nil {
+		t.Fatalf("ListAllRecords: %v", err)
+	}
+	if len(all) != 45 {
+		t.Errorf("ListAllRecords returned %d records, want 45", len(all))
+	}
+}
+
+func TestFailNext(t *testing.T) {
+	header := http.Header{"X-Ogw-Ratelimit-Reset": []string{"2"}}
+
+	t.Run("rate limited", func(t *testing.T) {
+		srv, client, appToken, tableID := newTable(t, feishu.WithoutRetry())
+		srv.FailNext(1, http.StatusTooManyRequests, 99991400, "request trigger frequency limit", header)
+		_, err := client.ListFields(appToken, tableID)
+		var apiErr *feishu.APIError
+		if !errors.Is(err, feishu.ErrRateLimited) || !errors.As(err, &apiErr) || apiErr.RetryAfter != 2*time.Second {
+			t.Fatalf("err = %v, want ErrRateLimited with RetryAfter 2s", err)
+		}
+		if _, err := client.ListFields(appToken, tableID); err != nil {
+			t.Errorf("second call: %v, want the failure to be consumed", err)
+		}
+	})
+
+	t.Run("retried", func(t *testing.T) {
+		srv, client, appToken, tableID := newTable(t, fastRetry)
+		srv.FailNext(2, http.StatusInternalServerError, 1255001, "internal error", nil)
+		before := srv.RequestCount()
+		if _, err := client.ListFields(appToken, tableID); err != nil {
+			t.Fatalf("ListFields: %v", err)
+		}
+		if n := srv.RequestCount() - before; n != 3 {
+			t.Errorf("sent %d requests, want 3", n)
+		}
+	})
+}
+
+func TestFailNextAfterProcessing(t *testing.T) {
+	srv, client, appToken, tableID := newTable(t, feishu.WithoutRetry())
+	srv.FailNextAfterProcessing(1, http.StatusBadGateway, 1255002, "bad gateway")
+	if _, err := client.CreateRecord(appToken, tableID, map[string]interface{}{"名称": "苹果"}); err == nil {
+		t.Fatal("CreateRecord: want error")
+	}
+	records := srv.Records(appToken, tableID)
+	if len(records) != 1 {
+		t.Fatalf("len(records) = %d, want the record written despite the error", len(records))
+	}
+	if name, _ := feishu.AsText(records[0]["名称"]); name != "苹果" {
+		t.Errorf("名称 = %q, want 苹果", name)
+	}
+}
+
+func TestSetLatencyAndRequestCount(t *testing.T) {
+	srv, client, appToken, tableID := newTable(t)
+	srv.SetLatency(100 * time.Millisecond)
+
+	before := srv.RequestCount()
+	start := time.Now()
+	if _, err := client.ListFields(appToken, tableID); err != nil {
+		t.Fatalf("ListFields: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("ListFields took %v, want at least the 100ms latency", elapsed)
+	}
+	if n := srv.RequestCount() - before; n != 1 {
+		t.Errorf("RequestCount increased by %d, want 1 (token requests are not counted)", n)
+	}
+}
@@ -0,0 +1,134 @@
+package fakeserver
+
+import (
+	"net/http"
+	"slices"
+)
+
+// 与飞书一致，新建数据表时自动创建的表格视图
+const (
+	defaultViewName = "表格"
+	viewTypeGrid    = "grid"
+)
+
+type view struct {
+	ID   string `json:"view_id"`
+	Name string `json:"view_name"`
+	Type string `json:"view_type"`
+}
+
+// view 按 ID 查找视图，调用方需持有锁
+func (t *table) view(viewID string) (int, *view, *apiError) {
+	for i, v := range t.views {
+		if v.ID == viewID {
+			return i, v, nil
+		}
+	}
+	return -1, nil, errorf(http.StatusNotFound, codeViewNotFound, "ViewIdNotFound")
+}
+
+func (s *Server) handleListViews(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	start, end, next, hasMore, err := page(r, len(t.views))
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	writeData(w, map[string]interface{}{
+		"items":      t.views[start:end],
+		"page_token": next,
+		"has_more":   hasMore,
+		"total":      len(t.views),
+	})
+}
+
+func (s *Server) handleCreateView(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name string `json:"view_name"`
+		Type string `json:"view_type"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	if body.Type == "" {
+		body.Type = viewTypeGrid
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	if body.Name == "" || slices.ContainsFunc(t.views, func(v *view) bool { return v.Name == body.Name }) {
+		writeResult(w, nil, errorf(http.StatusBadRequest, codeWrongRequest, "ViewNameDuplicated: %q", body.Name))
+		return
+	}
+
+	v := &view{ID: s.nextID("vew"), Name: body.Name, Type: body.Type}
+	t.views = append(t.views, v)
+	writeData(w, map[string]interface{}{"view": v})
+}
+
+// handlePatchView 修改视图名称
+func (s *Server) handlePatchView(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name string `json:"view_name"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	_, v, err := t.view(r.PathValue("view_id"))
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	if body.Name != "" && body.Name != v.Name {
+		if slices.ContainsFunc(t.views, func(v *view) bool { return v.Name == body.Name }) {
+			writeResult(w, nil, errorf(http.StatusBadRequest, codeWrongRequest, "ViewNameDuplicated: %q", body.Name))
+			return
+		}
+		v.Name = body.Name
+	}
+	writeData(w, map[string]interface{}{"view": v})
+}
+
+// handleDeleteView 删除视图，与飞书一致不允许删除最后一个视图
+func (s *Server) handleDeleteView(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, err := s.requestTable(r)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	i, _, err := t.view(r.PathValue("view_id"))
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	if len(t.views) == 1 {
+		writeResult(w, nil, errorf(http.StatusBadRequest, codeWrongRequest, "LastViewCannotDelete"))
+		return
+	}
+	t.views = slices.Delete(t.views, i, i+1)
+	writeData(w, map[string]interface{}{})
+}
@@ -0,0 +1,49 @@
+package fakeserver
+
+import "net/http"
+
+type wikiNode struct {
+	token    string
+	objType  string
+	objToken string
+	title    string
+}
+
+func (s *Server) registerWiki(mux *http.ServeMux) {
+	mux.HandleFunc("GET /open-apis/wiki/v2/spaces/get_node", s.handleGetWikiNode)
+}
+
+// AddWikiNode 添加一个指向已有文档的知识库节点，返回节点 token。
+// objType 为 docx 或 bitable，objToken 为对应的 document_id 或 app_token。
+func (s *Server) AddWikiNode(objType, objToken, title string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	node := &wikiNode{
+		token:    s.nextID("wikcn"),
+		objType:  objType,
+		objToken: objToken,
+		title:    title,
+	}
+	s.wikiNodes[node.token] = node
+	return node.token
+}
+
+func (s *Server) handleGetWikiNode(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	node, ok := s.wikiNodes[r.URL.Query().Get("token")]
+	if !ok {
+		writeError(w, http.StatusNotFound, codeWikiNodeNotFound, "node not found")
+		return
+	}
+	writeData(w, map[string]interface{}{
+		"node": map[string]interface{}{
+			"space_id":   "7000000000000000000",
+			"node_token": node.token,
+			"obj_token":  node.objToken,
+			"obj_type":   node.objType,
+			"node_type":  "origin",
+			"title":      node.title,
+		},
+	})
+}
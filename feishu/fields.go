@@ -0,0 +1,205 @@
+package feishu
+
+import (
+	"context"
+	"fmt"
+
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+)
+
+// Field 数据表中的一个字段（列）
+type Field struct {
+	ID          string    `json:"field_id"`
+	Name        string    `json:"field_name"`
+	Type        FieldType `json:"type"`
+	UIType      string    `json:"ui_type,omitempty"` // 界面上的展示类型，如进度、货币都是数字字段
+	IsPrimary   bool      `json:"is_primary"`
+	Description string    `json:"description,omitempty"`
+
+	// Property 字段属性，如单选、多选的选项，数字的格式，关联的数据表等
+	Property *larkbitable.AppTableFieldProperty `json:"property,omitempty"`
+}
+
+// newField 将 SDK 返回的字段转换为 Field
+func newField(f *larkbitable.AppTableFieldForList) *Field {
+	field := &Field{
+		ID:        StringValue(f.FieldId),
+		Name:      StringValue(f.FieldName),
+		UIType:    StringValue(f.UiType),
+		IsPrimary: f.IsPrimary != nil && *f.IsPrimary,
+		Property:  f.Property,
+	}
+	if f.Type != nil {
+		field.Type = FieldType(*f.Type)
+	}
+	// 未指定 text_field_as_array 时描述为字符串
+	if desc, ok := f.Description.(string); ok {
+		field.Description = desc
+	}
+	return field
+}
+
+// fieldFromSDK 将创建、更新字段接口返回的字段转换为 Field
+func fieldFromSDK(f *larkbitable.AppTableField) *Field {
+	field := &Field{
+		ID:        StringValue(f.FieldId),
+		Name:      StringValue(f.FieldName),
+		UIType:    StringValue(f.UiType),
+		IsPrimary: f.IsPrimary != nil && *f.IsPrimary,
+		Property:  f.Property,
+	}
+	if f.Type != nil {
+		field.Type = FieldType(*f.Type)
+	}
+	if f.Description != nil {
+		field.Description = StringValue(f.Description.Text)
+	}
+	return field
+}
+
+// ListFields 列出数据表中的所有字段，按数据表中的顺序排列，自动翻页
+func (c *MultiTableClient) ListFields(appToken, tableID string) ([]*Field, error) {
+	return c.ListFieldsContext(context.Background(), appToken, tableID)
+}
+
+// ListFieldsContext 列出数据表中的所有字段（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) ListFieldsContext(ctx context.Context, appToken, tableID string) ([]*Field, error) {
+	var (
+		fields    []*Field
+		pageToken string
+	)
+	for {
+		builder := larkbitable.NewListAppTableFieldReqBuilder().
+			AppToken(appToken).
+			TableId(tableID).
+			PageSize(DefaultPageSize)
+		if pageToken != "" {
+			builder.PageToken(pageToken)
+		}
+		req := builder.Build()
+
+		var resp *larkbitable.ListAppTableFieldResp
+		err := c.do(ctx, EndpointDefault, true, func() error {
+			var err error
+			resp, err = c.client.Bitable.AppTableField.List(ctx, req)
+			if err != nil {
+				return fmt.Errorf("列出字段失败: %w", err)
+			}
+			if !resp.Success() {
+				return newAPIError("列出字段", resp.ApiResp, resp.CodeError)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range resp.Data.Items {
+			fields = append(fields, newField(item))
+		}
+		pageToken = StringValue(resp.Data.PageToken)
+		if resp.Data.HasMore == nil || !*resp.Data.HasMore || pageToken == "" {
+			return fields, nil
+		}
+	}
+}
+
+// CreateField 在数据表末尾新增字段，返回创建后的字段（含 field_id）。
+// 请求携带 client_token，自动重试不会重复创建，见 WithClientToken。
+func (c *MultiTableClient) CreateField(appToken, tableID string, spec FieldSpec) (*Field, error) {
+	return c.CreateFieldContext(context.Background(), appToken, tableID, spec)
+}
+
+// CreateFieldContext 新增字段（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) CreateFieldContext(ctx context.Context, appToken, tableID string, spec FieldSpec) (*Field, error) {
+	req := larkbitable.NewCreateAppTableFieldReqBuilder().
+		AppToken(appToken).
+		TableId(tableID).
+		ClientToken(clientToken(ctx)).
+		AppTableField(spec.field()).
+		Build()
+
+	var resp *larkbitable.CreateAppTableFieldResp
+	err := c.do(ctx, EndpointDefault, true, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTableField.Create(ctx, req)
+		if err != nil {
+			return fmt.Errorf("创建字段失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("创建字段", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fieldFromSDK(resp.Data.Field), nil
+}
+
+// UpdateField 以 spec 整体替换字段的名称、类型和属性，返回更新后的字段。
+// 未设置的属性会被清空；修改字段类型时飞书会尽量转换已有的值，无法转换的值会丢失。
+func (c *MultiTableClient) UpdateField(appToken, tableID, fieldID string, spec FieldSpec) (*Field, error) {
+	return c.UpdateFieldContext(context.Background(), appToken, tableID, fieldID, spec)
+}
+
+// UpdateFieldContext 更新字段（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) UpdateFieldContext(ctx context.Context, appToken, tableID, fieldID string, spec FieldSpec) (*Field, error) {
+	req := larkbitable.NewUpdateAppTableFieldReqBuilder().
+		AppToken(appToken).
+		TableId(tableID).
+		FieldId(fieldID).
+		AppTableField(spec.field()).
+		Build()
+
+	var resp *larkbitable.UpdateAppTableFieldResp
+	err := c.do(ctx, EndpointDefault, true, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTableField.Update(ctx, req)
+		if err != nil {
+			return fmt.Errorf("更新字段失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("更新字段", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fieldFromSDK(resp.Data.Field), nil
+}
+
+// DeleteField 删除字段及所有记录中该字段的值，索引列（第一列）不能删除
+func (c *MultiTableClient) DeleteField(appToken, tableID, fieldID string) error {
+	return c.DeleteFieldContext(context.Background(), appToken, tableID, fieldID)
+}
+
+// DeleteFieldContext 删除字段（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) DeleteFieldContext(ctx context.Context, appToken, tableID, fieldID string) error {
+	req := larkbitable.NewDeleteAppTableFieldReqBuilder().
+		AppToken(appToken).
+		TableId(tableID).
+		FieldId(fieldID).
+		Build()
+
+	var resp *larkbitable.DeleteAppTableFieldResp
+	err := c.do(ctx, EndpointDefault, true, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTableField.Delete(ctx, req)
+		if err != nil {
+			return fmt.Errorf("删除字段失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("删除字段", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
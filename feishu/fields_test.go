@@ -0,0 +1,106 @@
+package feishu_test
+
+import (
+	"errors"
+	"testing"
+
+	"feishu_bitable_demo/feishu"
+)
+
+func TestParseFieldType(t *testing.T) {
+	tests := []struct {
+		in   string
+		want feishu.FieldType
+	}{
+		{"text", feishu.FieldTypeText},
+		{"Single_Select", feishu.FieldTypeSingleSelect},
+		{"auto_number", feishu.FieldTypeAutoNumber},
+		{"单选", feishu.FieldTypeSingleSelect},
+		{"最后更新时间", feishu.FieldTypeModifiedTime},
+		{"2", feishu.FieldTypeNumber},
+		{"1005", feishu.FieldTypeAutoNumber},
+	}
+	for _, tt := range tests {
+		if got, err := feishu.ParseFieldType(tt.in); err != nil || got != tt.want {
+			t.Errorf("ParseFieldType(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "0", "-1", "999", "string", "单选框"} {
+		if got, err := feishu.ParseFieldType(in); err == nil {
+			t.Errorf("ParseFieldType(%q) = %v, want error", in, got)
+		}
+	}
+}
+
+func TestFieldLifecycle(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t)
+
+	field, err := client.CreateField(appToken, tableID, feishu.FieldSpec{
+		Name:        "状态",
+		Type:        feishu.FieldTypeSingleSelect,
+		Property:    feishu.SelectOptions("在售", "下架"),
+		Description: "商品状态",
+	})
+	if err != nil {
+		t.Fatalf("CreateField: %v", err)
+	}
+	if field.ID == "" || field.Type != feishu.FieldTypeSingleSelect || field.Description != "商品状态" || len(field.Property.Options) != 2 {
+		t.Fatalf("created field = %+v", field)
+	}
+	if _, err := client.CreateField(appToken, tableID, feishu.FieldSpec{Name: "状态", Type: feishu.FieldTypeText}); err == nil {
+		t.Error("CreateField with a duplicate name: want error")
+	}
+
+	if _, err := client.CreateRecord(appToken, tableID, map[string]interface{}{"名称": "苹果", "状态": "在售"}); err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+	updated, err := client.UpdateField(appToken, tableID, field.ID, feishu.FieldSpec{
+		Name:     "销售状态",
+		Type:     feishu.FieldTypeSingleSelect,
+		Property: field.Property,
+	})
+	if err != nil {
+		t.Fatalf("UpdateField: %v", err)
+	}
+	if updated.ID != field.ID || updated.Name != "销售状态" || updated.Description != "" {
+		t.Errorf("updated field = %+v, want renamed with the description cleared", updated)
+	}
+	if records := srv.Records(appToken, tableID); records[0]["销售状态"] != "在售" {
+		t.Errorf("record after rename = %v, want the value moved to 销售状态", records[0])
+	}
+
+	fields, err := client.ListFields(appToken, tableID)
+	if err != nil {
+		t.Fatalf("ListFields: %v", err)
+	}
+	if len(fields) != 3 || fields[2].Name != "销售状态" {
+		t.Fatalf("fields = %+v", fields)
+	}
+	if err := client.DeleteField(appToken, tableID, fields[0].ID); err == nil {
+		t.Error("DeleteField of the primary field: want error")
+	}
+	if err := client.DeleteField(appToken, tableID, field.ID); err != nil {
+		t.Fatalf("DeleteField: %v", err)
+	}
+	if _, ok := srv.Records(appToken, tableID)[0]["销售状态"]; ok {
+		t.Error("record still has the deleted field")
+	}
+	if err := client.DeleteField(appToken, tableID, field.ID); !errors.Is(err, feishu.ErrNotFound) {
+		t.Errorf("DeleteField twice: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCreateFieldRetryIsIdempotent(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t, fastRetry)
+	srv.FailNextAfterProcessing(1, 502, 1255002, "bad gateway")
+	if _, err := client.CreateField(appToken, tableID, feishu.FieldSpec{Name: "备注", Type: feishu.FieldTypeText}); err != nil {
+		t.Fatalf("CreateField: %v", err)
+	}
+	fields, err := client.ListFields(appToken, tableID)
+	if err != nil {
+		t.Fatalf("ListFields: %v", err)
+	}
+	if len(fields) != 3 {
+		t.Errorf("len(fields) = %d, want 3 (the retried create must not add a second field)", len(fields))
+	}
+}
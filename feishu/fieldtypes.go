@@ -0,0 +1,321 @@
+package feishu
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+)
+
+// FieldType 多维表格字段类型
+type FieldType int
+
+const (
+	FieldTypeText         FieldType = 1    // 文本
+	FieldTypeNumber       FieldType = 2    // 数字，货币、进度、评分是数字字段的展示形态，见 UIType 常量
+	FieldTypeSingleSelect FieldType = 3    // 单选
+	FieldTypeMultiSelect  FieldType = 4    // 多选
+	FieldTypeDate         FieldType = 5    // 日期
+	FieldTypeCheckbox     FieldType = 7    // 复选框
+	FieldTypeUser         FieldType = 11   // 人员
+	FieldTypePhone        FieldType = 13   // 电话号码
+	FieldTypeURL          FieldType = 15   // 超链接
+	FieldTypeAttachment   FieldType = 17   // 附件
+	FieldTypeSingleLink   FieldType = 18   // 单向关联
+	FieldTypeLookup       FieldType = 19   // 查找引用
+	FieldTypeFormula      FieldType = 20   // 公式
+	FieldTypeDuplexLink   FieldType = 21   // 双向关联
+	FieldTypeLocation     FieldType = 22   // 地理位置
+	FieldTypeGroupChat    FieldType = 23   // 群组
+	FieldTypeCreatedTime  FieldType = 1001 // 创建时间
+	FieldTypeModifiedTime FieldType = 1002 // 最后更新时间
+	FieldTypeCreatedUser  FieldType = 1003 // 创建人
+	FieldTypeModifiedUser FieldType = 1004 // 修改人
+	FieldTypeAutoNumber   FieldType = 1005 // 自动编号
+)
+
+// 字段在界面上的展示类型（ui_type），与 FieldType 配合使用
+const (
+	UITypeCurrency = "Currency" // 货币，FieldTypeNumber
+	UITypeProgress = "Progress" // 进度，FieldTypeNumber
+	UITypeRating   = "Rating"   // 评分，FieldTypeNumber
+	UITypeEmail    = "Email"    // 邮箱，FieldTypeText
+	UITypeBarcode  = "Barcode"  // 条码，FieldTypeText
+)
+
+var fieldTypeNames = map[FieldType]string{
+	FieldTypeText:         "文本",
+	FieldTypeNumber:       "数字",
+	FieldTypeSingleSelect: "单选",
+	FieldTypeMultiSelect:  "多选",
+	FieldTypeDate:         "日期",
+	FieldTypeCheckbox:     "复选框",
+	FieldTypeUser:         "人员",
+	FieldTypePhone:        "电话号码",
+	FieldTypeURL:          "超链接",
+	FieldTypeAttachment:   "附件",
+	FieldTypeSingleLink:   "单向关联",
+	FieldTypeLookup:       "查找引用",
+	FieldTypeFormula:      "公式",
+	FieldTypeDuplexLink:   "双向关联",
+	FieldTypeLocation:     "地理位置",
+	FieldTypeGroupChat:    "群组",
+	FieldTypeCreatedTime:  "创建时间",
+	FieldTypeModifiedTime: "最后更新时间",
+	FieldTypeCreatedUser:  "创建人",
+	FieldTypeModifiedUser: "修改人",
+	FieldTypeAutoNumber:   "自动编号",
+}
+
+// fieldTypeKeys 字段类型的英文名称，用于 schema 文件和命令行参数
+var fieldTypeKeys = map[string]FieldType{
+	"text":          FieldTypeText,
+	"number":        FieldTypeNumber,
+	"single_select": FieldTypeSingleSelect,
+	"multi_select":  FieldTypeMultiSelect,
+	"date":          FieldTypeDate,
+	"checkbox":      FieldTypeCheckbox,
+	"user":          FieldTypeUser,
+	"phone":         FieldTypePhone,
+	"url":           FieldTypeURL,
+	"attachment":    FieldTypeAttachment,
+	"single_link":   FieldTypeSingleLink,
+	"lookup":        FieldTypeLookup,
+	"formula":       FieldTypeFormula,
+	"duplex_link":   FieldTypeDuplexLink,
+	"location":      FieldTypeLocation,
+	"group_chat":    FieldTypeGroupChat,
+	"created_time":  FieldTypeCreatedTime,
+	"modified_time": FieldTypeModifiedTime,
+	"created_user":  FieldTypeCreatedUser,
+	"modified_user": FieldTypeModifiedUser,
+	"auto_number":   FieldTypeAutoNumber,
+}
+
+// ParseFieldType 解析字段类型：英文名称（如 text、single_select，不区分大小写）、
+// 中文名称（如 单选，见 String）或已知的类型编号（如 3）
+func ParseFieldType(s string) (FieldType, error) {
+	if t, ok := fieldTypeKeys[strings.ToLower(s)]; ok {
+		return t, nil
+	}
+	for t, name := range fieldTypeNames {
+		if name == s {
+			return t, nil
+		}
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		if _, ok := fieldTypeNames[FieldType(n)]; ok {
+			return FieldType(n), nil
+		}
+	}
+	return 0, fmt.Errorf("未知的字段类型 %q", s)
+}
+
+// String 返回字段类型的中文名称，未知类型返回 "类型 <编号>"
+func (t FieldType) String() string {
+	if name, ok := fieldTypeNames[t]; ok {
+		return name
+	}
+	return "类型 " + strconv.Itoa(int(t))
+}
+
+// ReadOnly 判断字段的值是否由飞书计算或自动填写，不能通过记录接口写入
+func (t FieldType) ReadOnly() bool {
+	switch t {
+	case FieldTypeLookup, FieldTypeFormula, FieldTypeCreatedTime, FieldTypeModifiedTime,
+		FieldTypeCreatedUser, FieldTypeModifiedUser, FieldTypeAutoNumber:
+		return true
+	}
+	return false
+}
+
+// FieldSpec 字段定义，用于创建数据表（见 Headers）以及 CreateField、UpdateField
+type FieldSpec struct {
+	Name        string
+	Type        FieldType
+	UIType      string                             // 可选，如 UITypeCurrency
+	Property    *larkbitable.AppTableFieldProperty // 可选，由 SelectProperty 等函数构造
+	Description string                             // 可选，字段描述
+}
+
+// Header 转换为创建数据表时使用的字段定义
+func (s FieldSpec) Header() *larkbitable.AppTableCreateHeader {
+	builder := larkbitable.NewAppTableCreateHeaderBuilder().
+		FieldName(s.Name).
+		Type(int(s.Type))
+	if s.UIType != "" {
+		builder.UiType(s.UIType)
+	}
+	if s.Property != nil {
+		builder.Property(s.Property)
+	}
+	if s.Description != "" {
+		builder.Description(larkbitable.NewAppTableFieldDescriptionBuilder().Text(s.Description).Build())
+	}
+	return builder.Build()
+}
+
+// field 转换为创建、更新字段接口的请求体
+func (s FieldSpec) field() *larkbitable.AppTableField {
+	builder := larkbitable.NewAppTableFieldBuilder().
+		FieldName(s.Name).
+		Type(int(s.Type))
+	if s.UIType != "" {
+		builder.UiType(s.UIType)
+	}
+	if s.Property != nil {
+		builder.Property(s.Property)
+	}
+	if s.Description != "" {
+		builder.Description(larkbitable.NewAppTableFieldDescriptionBuilder().Text(s.Description).Build())
+	}
+	return builder.Build()
+}
+
+// Headers 将多个字段定义转换为 CreateTable、CreateAppAndTable 的 fields 参数
+func Headers(specs ...FieldSpec) []*larkbitable.AppTableCreateHeader {
+	headers := make([]*larkbitable.AppTableCreateHeader, 0, len(specs))
+	for _, spec := range specs {
+		headers = append(headers, spec.Header())
+	}
+	return headers
+}
+
+// 数字、货币、公式字段的显示格式
+const (
+	NumberFormatInteger        = "0"
+	NumberFormatDecimal1       = "0.0"
+	NumberFormatDecimal2       = "0.00"
+	NumberFormatThousands      = "1,000"
+	NumberFormatThousandsFloat = "1,000.00"
+	NumberFormatPercent        = "%"
+	NumberFormatPercentFloat   = "0.00%"
+)
+
+// 日期、创建时间、最后更新时间字段的显示格式
+const (
+	DateFormatDate         = "yyyy/MM/dd"
+	DateFormatDateTime     = "yyyy/MM/dd HH:mm"
+	DateFormatDateISO      = "yyyy-MM-dd"
+	DateFormatDateTimeISO  = "yyyy-MM-dd HH:mm"
+	DateFormatMonthDay     = "MM-dd"
+	DateFormatMonthDayYear = "MM/dd/yyyy"
+	DateFormatDayMonthYear = "dd/MM/yyyy"
+)
+
+// SelectOption 单选、多选字段的选项
+type SelectOption struct {
+	Name  string
+	Color int // 颜色编号，取值 0-54
+}
+
+// SelectProperty 单选、多选字段的属性
+func SelectProperty(options ...SelectOption) *larkbitable.AppTableFieldProperty {
+	items := make([]*larkbitable.AppTableFieldPropertyOption, 0, len(options))
+	for _, option := range options {
+		items = append(items, larkbitable.NewAppTableFieldPropertyOptionBuilder().
+			Name(option.Name).
+			Color(option.Color).
+			Build())
+	}
+	return larkbitable.NewAppTableFieldPropertyBuilder().Options(items).Build()
+}
+
+// SelectOptions 单选、多选字段的属性，选项按顺序使用颜色 0、1、2……
+func SelectOptions(names ...string) *larkbitable.AppTableFieldProperty {
+	options := make([]SelectOption, 0, len(names))
+	for i, name := range names {
+		options = append(options, SelectOption{Name: name, Color: i % 55})
+	}
+	return SelectProperty(options...)
+}
+
+// NumberProperty 数字字段的属性，formatter 为 NumberFormatInteger 等显示格式
+func NumberProperty(formatter string) *larkbitable.AppTableFieldProperty {
+	return larkbitable.NewAppTableFieldPropertyBuilder().Formatter(formatter).Build()
+}
+
+// CurrencyProperty 货币字段（UITypeCurrency）的属性，currencyCode 如 "CNY"、"USD"
+func CurrencyProperty(currencyCode, formatter string) *larkbitable.AppTableFieldProperty {
+	return larkbitable.NewAppTableFieldPropertyBuilder().
+		CurrencyCode(currencyCode).
+		Formatter(formatter).
+		Build()
+}
+
+// ProgressProperty 进度字段（UITypeProgress）的属性，取值范围为 [min, max]
+func ProgressProperty(formatter string, min, max float64) *larkbitable.AppTableFieldProperty {
+	return larkbitable.NewAppTableFieldPropertyBuilder().
+		Formatter(formatter).
+		Min(min).
+		Max(max).
+		RangeCustomize(true).
+		Build()
+}
+
+// RatingProperty 评分字段（UITypeRating）的属性，symbol 为展示的符号，如 "star"、"heart"、"thumbsup"
+func RatingProperty(symbol string, min, max float64) *larkbitable.AppTableFieldProperty {
+	return larkbitable.NewAppTableFieldPropertyBuilder().
+		Rating(larkbitable.NewRatingBuilder().Symbol(symbol).Build()).
+		Min(min).
+		Max(max).
+		Build()
+}
+
+// DateProperty 日期字段的属性，dateFormat 为 DateFormatDate 等显示格式，autoFill 表示新记录自动填写创建时间
+func DateProperty(dateFormat string, autoFill bool) *larkbitable.AppTableFieldProperty {
+	return larkbitable.NewAppTableFieldPropertyBuilder().
+		DateFormatter(dateFormat).
+		AutoFill(autoFill).
+		Build()
+}
+
+// UserProperty 人员字段的属性，multiple 表示允许添加多个成员
+func UserProperty(multiple bool) *larkbitable.AppTableFieldProperty {
+	return larkbitable.NewAppTableFieldPropertyBuilder().Multiple(multiple).Build()
+}
+
+// LinkProperty 单向关联字段的属性，tableID 为关联的数据表
+func LinkProperty(tableID string, multiple bool) *larkbitable.AppTableFieldProperty {
+	return larkbitable.NewAppTableFieldPropertyBuilder().
+		TableId(tableID).
+		Multiple(multiple).
+		Build()
+}
+
+// DuplexLinkProperty 双向关联字段的属性，backFieldName 为关联的数据表中自动创建的对应字段名
+func DuplexLinkProperty(tableID, backFieldName string, multiple bool) *larkbitable.AppTableFieldProperty {
+	return larkbitable.NewAppTableFieldPropertyBuilder().
+		TableId(tableID).
+		BackFieldName(backFieldName).
+		Multiple(multiple).
+		Build()
+}
+
+// FormulaProperty 公式字段的属性，formatter 为空时使用默认显示格式
+func FormulaProperty(expression, formatter string) *larkbitable.AppTableFieldProperty {
+	builder := larkbitable.NewAppTableFieldPropertyBuilder().FormulaExpression(expression)
+	if formatter != "" {
+		builder.Formatter(formatter)
+	}
+	return builder.Build()
+}
+
+// AutoNumberProperty 自动编号字段的属性，编号为自增数字
+func AutoNumberProperty() *larkbitable.AppTableFieldProperty {
+	return larkbitable.NewAppTableFieldPropertyBuilder().
+		AutoSerial(larkbitable.NewAppFieldPropertyAutoSerialBuilder().Type("auto_increment_number").Build()).
+		Build()
+}
+
+// LocationProperty 地理位置字段的属性，onlyMobile 表示只允许在移动端定位输入
+func LocationProperty(onlyMobile bool) *larkbitable.AppTableFieldProperty {
+	inputType := "not_limit"
+	if onlyMobile {
+		inputType = "only_mobile"
+	}
+	return larkbitable.NewAppTableFieldPropertyBuilder().
+		Location(larkbitable.NewAppFieldPropertyLocationBuilder().InputType(inputType).Build()).
+		Build()
+}
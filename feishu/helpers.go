@@ -66,3 +66,11 @@ func CreatePhoneField(phone string) interface{} {
 func CreateLocationField(location string) interface{} {
 	return location
 }
+
+// StringValue 返回字符串指针的值，nil 时返回空字符串，用于读取 SDK 返回的可选字段
+func StringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,55 @@
+package feishu
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"fmt"
+	"strconv"
+)
+
+// clientTokenKey context 中保存 client_token 的键
+type clientTokenKey struct{}
+
+// WithClientToken 为 ctx 上发起的创建调用（CreateRecordContext、BatchCreateRecordsContext、CreateFieldContext）指定 client_token。
+//
+// 创建记录时客户端总会携带 client_token，自动重试时复用同一个值，服务端据此保证同一批记录只创建一次。
+// 未指定时每次调用自动生成；调用超时等情况下需要由调用方再次调用时，应先用 NewClientToken 生成并保存，
+// 重新调用时传入同一个值和相同的记录。
+func WithClientToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, clientTokenKey{}, token)
+}
+
+// NewClientToken 生成一个新的 client_token（UUID v4）
+func NewClientToken() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return formatUUID(b)
+}
+
+// clientToken 返回 ctx 中指定的 client_token，未指定时生成新的值
+func clientToken(ctx context.Context) string {
+	if token, ok := ctx.Value(clientTokenKey{}).(string); ok && token != "" {
+		return token
+	}
+	return NewClientToken()
+}
+
+// chunkClientToken 为分批请求中从第 start 条开始的批次生成 client_token。
+// 第一批直接使用 token，其余批次由 token 和 start 确定性地派生，保证重新调用时每批的值不变。
+func chunkClientToken(token string, start int) string {
+	if start == 0 {
+		return token
+	}
+	sum := sha1.Sum([]byte(token + "/" + strconv.Itoa(start)))
+	var b [16]byte
+	copy(b[:], sum[:])
+	return formatUUID(b)
+}
+
+// formatUUID 按 UUID v4 的格式设置版本位并格式化
+func formatUUID(b [16]byte) string {
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,47 @@
+package feishu
+
+import (
+	"context"
+	"regexp"
+	"testing"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestChunkClientToken(t *testing.T) {
+	token := NewClientToken()
+	if !uuidPattern.MatchString(token) {
+		t.Fatalf("NewClientToken() = %q, want a UUID v4", token)
+	}
+	if got := chunkClientToken(token, 0); got != token {
+		t.Errorf("chunkClientToken(token, 0) = %q, want the token itself", got)
+	}
+
+	seen := map[string]int{token: 0}
+	for start := MaxBatchSize; start <= 10*MaxBatchSize; start += MaxBatchSize {
+		got := chunkClientToken(token, start)
+		if !uuidPattern.MatchString(got) {
+			t.Errorf("chunkClientToken(token, %d) = %q, want a UUID v4", start, got)
+		}
+		if prev, ok := seen[got]; ok {
+			t.Errorf("chunks %d and %d share client_token %q", prev, start, got)
+		}
+		seen[got] = start
+		if again := chunkClientToken(token, start); again != got {
+			t.Errorf("chunkClientToken(token, %d) is not deterministic: %q != %q", start, got, again)
+		}
+	}
+	if chunkClientToken(NewClientToken(), MaxBatchSize) == chunkClientToken(token, MaxBatchSize) {
+		t.Error("different tokens derived the same chunk client_token")
+	}
+}
+
+func TestClientToken(t *testing.T) {
+	ctx := WithClientToken(context.Background(), "fixed")
+	if got := clientToken(ctx); got != "fixed" {
+		t.Errorf("clientToken = %q, want fixed", got)
+	}
+	if a, b := clientToken(context.Background()), clientToken(context.Background()); a == b {
+		t.Errorf("clientToken without WithClientToken returned %q twice, want a new token per call", a)
+	}
+}
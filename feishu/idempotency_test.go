@@ -0,0 +1,66 @@
+package feishu_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"feishu_bitable_demo/feishu"
+)
+
+func TestCreateRecordRetryIsIdempotent(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t, fastRetry)
+	srv.FailNextAfterProcessing(1, http.StatusBadGateway, 1255002, "bad gateway")
+
+	before := srv.RequestCount()
+	record, err := client.CreateRecord(appToken, tableID, map[string]interface{}{"名称": "苹果"})
+	if err != nil {
+		t.Fatalf("CreateRecord: %v", err)
+	}
+	if n := srv.RequestCount() - before; n != 2 {
+		t.Errorf("sent %d requests, want 2 (one retry)", n)
+	}
+	if n := len(srv.Records(appToken, tableID)); n != 1 {
+		t.Fatalf("server has %d records, want exactly 1", n)
+	}
+	if got, err := client.GetRecord(appToken, tableID, record.ID); err != nil || got.ID != record.ID {
+		t.Errorf("GetRecord(%s) = %v, %v; want the record created by the first attempt", record.ID, got, err)
+	}
+}
+
+func TestBatchCreateRecordsRetryIsIdempotent(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t, fastRetry)
+	srv.FailNextAfterProcessing(1, http.StatusBadGateway, 1255002, "bad gateway")
+
+	result, err := client.BatchCreateRecords(appToken, tableID, newRecords(3))
+	if err != nil {
+		t.Fatalf("BatchCreateRecords: %v", err)
+	}
+	if n := len(srv.Records(appToken, tableID)); n != 3 {
+		t.Errorf("server has %d records, want 3", n)
+	}
+	if n := len(result.Succeeded()); n != 3 {
+		t.Errorf("%d records succeeded, want 3", n)
+	}
+}
+
+func TestWithClientTokenAcrossCalls(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t, feishu.WithoutRetry())
+	ctx := feishu.WithClientToken(context.Background(), feishu.NewClientToken())
+	records := newRecords(feishu.MaxBatchSize + 10)
+
+	// 第一次调用的响应丢失，调用方用同一个 client_token 和相同的记录重新调用
+	srv.FailNextAfterProcessing(2, http.StatusBadGateway, 1255002, "bad gateway")
+	if _, err := client.BatchCreateRecordsContext(ctx, appToken, tableID, records); err == nil {
+		t.Fatal("first BatchCreateRecordsContext: want error")
+	}
+	if n := len(srv.Records(appToken, tableID)); n != len(records) {
+		t.Fatalf("server has %d records after the lost responses, want %d", n, len(records))
+	}
+	if _, err := client.BatchCreateRecordsContext(ctx, appToken, tableID, records); err != nil {
+		t.Fatalf("second BatchCreateRecordsContext: %v", err)
+	}
+	if n := len(srv.Records(appToken, tableID)); n != len(records) {
+		t.Errorf("server has %d records, want %d (each chunk created once)", n, len(records))
+	}
+}
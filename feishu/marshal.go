@@ -0,0 +1,538 @@
+package feishu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Link 超链接字段的值
+type Link struct {
+	Text string `json:"text"`
+	URL  string `json:"link"`
+}
+
+// Attachment 附件字段中的一个文件。写入时只需要 FileToken（上传素材后获得），其余字段由飞书返回。
+type Attachment struct {
+	FileToken string `json:"file_token"`
+	Name      string `json:"name,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+	URL       string `json:"url,omitempty"`
+	TmpURL    string `json:"tmp_url,omitempty"`
+}
+
+// FieldTypeError 结构体字段与多维表格字段的值类型不匹配
+type FieldTypeError struct {
+	Field  string       // 多维表格字段名
+	GoType reflect.Type // 结构体字段的类型
+	Value  interface{}  // 无法转换的字段值，MarshalFields 时为 nil
+	Reason string
+}
+
+func (e *FieldTypeError) Error() string {
+	if e.Value == nil {
+		return fmt.Sprintf("字段 %s: 不支持的类型 %s: %s", e.Field, e.GoType, e.Reason)
+	}
+	data, err := json.Marshal(e.Value)
+	if err != nil {
+		data = []byte(fmt.Sprint(e.Value))
+	}
+	return fmt.Sprintf("字段 %s: 无法将 %s 转换为 %s: %s", e.Field, data, e.GoType, e.Reason)
+}
+
+// MarshalFields 将结构体转换为记录字段，v 为结构体或结构体指针。
+//
+// 结构体字段通过 bitable 标签指定对应的多维表格字段名，没有标签或标签为 "-" 的字段不参与转换：
+//
+//	type Product struct {
+//		ID     string       `bitable:",record_id"` // 记录 ID，不写入字段
+//		Name   string       `bitable:"产品名称"`
+//		Stock  int          `bitable:"库存数量"`
+//		Status string       `bitable:"状态"`
+//		Tags   []string     `bitable:"标签"`
+//		Since  time.Time    `bitable:"创建时间,omitempty"`
+//		OnSale bool         `bitable:"是否上架"`
+//		Owner  []User       `bitable:"负责人"`
+//		Site   *Link        `bitable:"官网"`
+//		Files  []Attachment `bitable:"附件"`
+//	}
+//
+// 支持的类型：string（文本、单选、电话等）、整数和浮点数（数字）、bool（复选框）、
+// []string（多选）、time.Time（日期）、User 和 []User（人员）、Link（超链接）、
+// Attachment 和 []Attachment（附件），以及上述类型的指针；interface{} 原样写入。
+// 带 omitempty 选项的字段为零值时不写入；其余字段的零值会写入（清空）对应字段，
+// 零值的 time.Time、nil 指针以及没有 ID、FileToken 或 URL 的 User、Attachment、Link 写入 null，
+// 切片中没有 ID 或 FileToken 的元素会被忽略。带 readonly 选项的字段（公式、查找引用、创建时间等）只读取不写入。
+func MarshalFields(v interface{}) (map[string]interface{}, error) {
+	rv, err := structValue(v, false)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := cachedFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{}, len(infos))
+	for _, info := range infos {
+		if info.recordID || info.readOnly {
+			continue
+		}
+		fv := rv.FieldByIndex(info.index)
+		if info.omitEmpty && fv.IsZero() {
+			continue
+		}
+		value, err := marshalValue(fv)
+		if err != nil {
+			return nil, &FieldTypeError{Field: info.name, GoType: fv.Type(), Reason: err.Error()}
+		}
+		fields[info.name] = value
+	}
+	return fields, nil
+}
+
+// UnmarshalFields 将记录字段写入 v 指向的结构体，标签的用法与 MarshalFields 相同。
+// fields 中不存在或为 null 的字段会被置为零值，字段值与结构体字段类型不匹配时返回 *FieldTypeError。
+func UnmarshalFields(fields map[string]interface{}, v interface{}) error {
+	rv, err := structValue(v, true)
+	if err != nil {
+		return err
+	}
+	infos, err := cachedFields(rv.Type())
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		if info.recordID {
+			continue
+		}
+		fv := rv.FieldByIndex(info.index)
+		raw := fields[info.name]
+		if err := unmarshalValue(normalizeValue(raw), fv); err != nil {
+			return &FieldTypeError{Field: info.name, GoType: fv.Type(), Value: raw, Reason: err.Error()}
+		}
+	}
+	return nil
+}
+
+// UnmarshalRecord 将记录写入 v 指向的结构体，除字段外还会写入带 record_id 选项的结构体字段
+func UnmarshalRecord(record *Record, v interface{}) error {
+	if err := UnmarshalFields(record.Fields, v); err != nil {
+		return err
+	}
+	rv, _ := structValue(v, true)
+	infos, _ := cachedFields(rv.Type())
+	for _, info := range infos {
+		if info.recordID {
+			rv.FieldByIndex(info.index).SetString(record.ID)
+		}
+	}
+	return nil
+}
+
+// recordIDOf 返回结构体中带 record_id 选项的字段的值，没有这样的字段时返回错误
+func recordIDOf(v interface{}) (string, error) {
+	rv, err := structValue(v, false)
+	if err != nil {
+		return "", err
+	}
+	infos, err := cachedFields(rv.Type())
+	if err != nil {
+		return "", err
+	}
+	for _, info := range infos {
+		if info.recordID {
+			return rv.FieldByIndex(info.index).String(), nil
+		}
+	}
+	return "", fmt.Errorf("%s 没有带 record_id 选项的字段", rv.Type())
+}
+
+// structValue 取出 v 对应的结构体，settable 为 true 时 v 必须是非 nil 的结构体指针
+func structValue(v interface{}, settable bool) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}, errors.New("需要非 nil 的结构体指针")
+		}
+		rv = rv.Elem()
+	} else if settable {
+		return reflect.Value{}, fmt.Errorf("需要结构体指针，实际为 %T", v)
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("需要结构体，实际为 %T", v)
+	}
+	return rv, nil
+}
+
+// structField 结构体字段与多维表格字段的对应关系
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	readOnly  bool
+	recordID  bool
+}
+
+var fieldCache sync.Map // reflect.Type -> []structField
+
+// cachedFields 解析结构体的 bitable 标签，结果按类型缓存
+func cachedFields(t reflect.Type) ([]structField, error) {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]structField), nil
+	}
+	infos, err := typeFields(t, nil)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(infos))
+	for _, info := range infos {
+		if info.recordID {
+			continue
+		}
+		if seen[info.name] {
+			return nil, fmt.Errorf("%s: 多个结构体字段对应同一个字段 %s", t, info.name)
+		}
+		seen[info.name] = true
+	}
+	fieldCache.Store(t, infos)
+	return infos, nil
+}
+
+// typeFields 按声明顺序收集带 bitable 标签的字段，没有标签的匿名结构体字段会展开
+func typeFields(t reflect.Type, index []int) ([]structField, error) {
+	var infos []structField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		idx := append(append([]int(nil), index...), i)
+		tag, ok := sf.Tag.Lookup("bitable")
+		if !ok {
+			if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+				embedded, err := typeFields(sf.Type, idx)
+				if err != nil {
+					return nil, err
+				}
+				infos = append(infos, embedded...)
+			}
+			continue
+		}
+		if tag == "-" || !sf.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		info := structField{name: name, index: idx}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "":
+			case "omitempty":
+				info.omitEmpty = true
+			case "readonly":
+				info.readOnly = true
+			case "record_id":
+				info.recordID = true
+			default:
+				return nil, fmt.Errorf("%s.%s: 未知的标签选项 %q", t, sf.Name, opt)
+			}
+		}
+		if info.recordID && sf.Type.Kind() != reflect.String {
+			return nil, fmt.Errorf("%s.%s: record_id 字段必须是 string 类型", t, sf.Name)
+		}
+		if !info.recordID && name == "" {
+			return nil, fmt.Errorf("%s.%s: bitable 标签缺少字段名", t, sf.Name)
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	userType       = reflect.TypeOf(User{})
+	linkType       = reflect.TypeOf(Link{})
+	attachmentType = reflect.TypeOf(Attachment{})
+)
+
+// marshalValue 将结构体字段的值转换为写入格式
+func marshalValue(v reflect.Value) (interface{}, error) {
+	switch v.Type() {
+	case timeType:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return nil, nil
+		}
+		return t.UnixMilli(), nil
+	case userType:
+		user := v.Interface().(User)
+		if user.ID == "" {
+			return nil, nil
+		}
+		return []map[string]string{{"id": user.ID}}, nil
+	case linkType:
+		link := v.Interface().(Link)
+		if link.URL == "" {
+			return nil, nil
+		}
+		return map[string]string{"link": link.URL, "text": link.Text}, nil
+	case attachmentType:
+		attachment := v.Interface().(Attachment)
+		if attachment.FileToken == "" {
+			return nil, nil
+		}
+		return []map[string]string{{"file_token": attachment.FileToken}}, nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Kind() == reflect.Interface {
+			return v.Interface(), nil
+		}
+		return marshalValue(v.Elem())
+	case reflect.Slice:
+		switch elem := v.Type().Elem(); {
+		case elem.Kind() == reflect.String:
+			values := make([]string, v.Len())
+			for i := range values {
+				values[i] = v.Index(i).String()
+			}
+			return values, nil
+		case elem == userType:
+			values := make([]map[string]string, 0, v.Len())
+			for i := 0; i < v.Len(); i++ {
+				if user := v.Index(i).Interface().(User); user.ID != "" {
+					values = append(values, map[string]string{"id": user.ID})
+				}
+			}
+			return values, nil
+		case elem == attachmentType:
+			values := make([]map[string]string, 0, v.Len())
+			for i := 0; i < v.Len(); i++ {
+				if attachment := v.Index(i).Interface().(Attachment); attachment.FileToken != "" {
+					values = append(values, map[string]string{"file_token": attachment.FileToken})
+				}
+			}
+			return values, nil
+		}
+	}
+	return nil, errors.New("请使用 string、数字、bool、[]string、time.Time、User、Link 或 Attachment")
+}
+
+// normalizeValue 经过 JSON 转换统一字段值的格式，使读取格式和 MarshalFields 生成的写入格式都能被解析
+func normalizeValue(v interface{}) interface{} {
+	switch v.(type) {
+	case nil, string, bool, float64:
+		return v
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return v
+	}
+	return generic
+}
+
+// unmarshalValue 将 JSON 解码后的字段值写入结构体字段
+func unmarshalValue(raw interface{}, v reflect.Value) error {
+	if raw == nil {
+		v.SetZero()
+		return nil
+	}
+	raw = unwrapFormula(raw, isSliceType(v.Type()))
+
+	switch v.Type() {
+	case timeType:
+		ms, err := numberFromValue(raw)
+		if err != nil {
+			return errors.New("日期字段应为毫秒时间戳")
+		}
+		v.Set(reflect.ValueOf(time.UnixMilli(int64(ms))))
+		return nil
+	case userType, attachmentType:
+		if _, ok := raw.(map[string]interface{}); ok {
+			return decodeJSONValue(raw, v) // []User、[]Attachment 中的单个值
+		}
+		values, ok := raw.([]interface{})
+		if !ok {
+			return errors.New("应为对象数组")
+		}
+		if len(values) > 1 {
+			return fmt.Errorf("包含 %d 个值，请使用切片类型", len(values))
+		}
+		if len(values) == 0 {
+			v.SetZero()
+			return nil
+		}
+		return decodeJSONValue(values[0], v)
+	case linkType:
+		if _, ok := raw.(map[string]interface{}); !ok {
+			return errors.New("超链接字段应为 {link, text} 对象")
+		}
+		return decodeJSONValue(raw, v)
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		v.Set(reflect.ValueOf(raw))
+		return nil
+	case reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+		if err := unmarshalValue(raw, elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case reflect.String:
+		s, ok := textFromValue(raw)
+		if !ok {
+			return errors.New("不是文本")
+		}
+		v.SetString(s)
+		return nil
+	case reflect.Bool:
+		b, ok := raw.(bool)
+		if !ok {
+			return errors.New("不是复选框的值")
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := numberFromValue(raw)
+		if err != nil {
+			return err
+		}
+		if n != math.Trunc(n) {
+			return errors.New("不是整数")
+		}
+		if v.OverflowInt(int64(n)) {
+			return errors.New("超出取值范围")
+		}
+		v.SetInt(int64(n))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := numberFromValue(raw)
+		if err != nil {
+			return err
+		}
+		if n != math.Trunc(n) || n < 0 {
+			return errors.New("不是非负整数")
+		}
+		if v.OverflowUint(uint64(n)) {
+			return errors.New("超出取值范围")
+		}
+		v.SetUint(uint64(n))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		n, err := numberFromValue(raw)
+		if err != nil {
+			return err
+		}
+		if v.OverflowFloat(n) {
+			return errors.New("超出取值范围")
+		}
+		v.SetFloat(n)
+		return nil
+	case reflect.Slice:
+		values, ok := raw.([]interface{})
+		if !ok {
+			if s, isText := raw.(string); isText && v.Type().Elem().Kind() == reflect.String {
+				values = []interface{}{s} // 单选字段的值
+			} else {
+				return errors.New("不是数组")
+			}
+		}
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, item := range values {
+			if err := unmarshalValue(item, slice.Index(i)); err != nil {
+				return fmt.Errorf("第 %d 个值: %w", i+1, err)
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+	return errors.New("请使用 string、数字、bool、[]string、time.Time、User、Link 或 Attachment")
+}
+
+// isSliceType 判断 t 是否为切片或切片指针
+func isSliceType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Slice
+}
+
+// decodeJSONValue 将 JSON 对象解码到 User、Link 等结构体
+func decodeJSONValue(raw interface{}, v reflect.Value) error {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	ptr := reflect.New(v.Type())
+	if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+		return errors.New("对象格式不正确")
+	}
+	v.Set(ptr.Elem())
+	return nil
+}
+
+// textFromValue 取出文本类字段的值：字符串、富文本片段，或超链接等带 text 的对象
+func textFromValue(raw interface{}) (string, bool) {
+	switch raw := raw.(type) {
+	case string:
+		return raw, true
+	case map[string]interface{}:
+		s, ok := raw["text"].(string)
+		return s, ok
+	case []interface{}:
+		var b strings.Builder
+		for _, item := range raw {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				return "", false
+			}
+			s, ok := m["text"].(string)
+			if !ok {
+				return "", false
+			}
+			b.WriteString(s)
+		}
+		return b.String(), true
+	}
+	return "", false
+}
+
+// numberFromValue 取出数字类字段的值，也接受数字文本
+func numberFromValue(raw interface{}) (float64, error) {
+	switch raw := raw.(type) {
+	case float64:
+		return raw, nil
+	case string:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return 0, errors.New("不是数字")
+		}
+		return n, nil
+	}
+	return 0, errors.New("不是数字")
+}
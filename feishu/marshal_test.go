@@ -0,0 +1,206 @@
+package feishu_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"feishu_bitable_demo/feishu"
+)
+
+type product struct {
+	ID      string              `bitable:",record_id"`
+	Name    string              `bitable:"名称"`
+	Stock   int                 `bitable:"库存"`
+	Price   float64             `bitable:"价格"`
+	Status  string              `bitable:"状态"`
+	Tags    []string            `bitable:"标签"`
+	Since   time.Time           `bitable:"上架日期"`
+	OnSale  bool                `bitable:"是否上架"`
+	Owner   feishu.User         `bitable:"负责人"`
+	Site    *feishu.Link        `bitable:"官网"`
+	Files   []feishu.Attachment `bitable:"附件"`
+	Note    string              `bitable:"备注,omitempty"`
+	Total   float64             `bitable:"总价,readonly"`
+	Ignored string
+}
+
+func TestMarshalFields(t *testing.T) {
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	p := product{
+		ID:     "rec1",
+		Name:   "苹果",
+		Stock:  10,
+		Price:  5.5,
+		Status: "在售",
+		Tags:   []string{"水果", "新品"},
+		Since:  since,
+		OnSale: true,
+		Owner:  feishu.User{ID: "ou_1", Name: "张三"},
+		Site:   &feishu.Link{Text: "官网", URL: "https://example.com"},
+		Files:  []feishu.Attachment{{FileToken: "box1", Name: "a.png"}},
+		Total:  55,
+	}
+	fields, err := feishu.MarshalFields(&p)
+	if err != nil {
+		t.Fatalf("MarshalFields: %v", err)
+	}
+	want := map[string]interface{}{
+		"名称":   "苹果",
+		"库存":   int64(10),
+		"价格":   5.5,
+		"状态":   "在售",
+		"标签":   []string{"水果", "新品"},
+		"上架日期": since.UnixMilli(),
+		"是否上架": true,
+		"负责人":  []map[string]string{{"id": "ou_1"}},
+		"官网":   map[string]string{"link": "https://example.com", "text": "官网"},
+		"附件":   []map[string]string{{"file_token": "box1"}},
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("MarshalFields =\n%v\nwant\n%v", fields, want)
+	}
+}
+
+func TestMarshalFieldsZeroValues(t *testing.T) {
+	fields, err := feishu.MarshalFields(product{Files: []feishu.Attachment{{Name: "未上传"}}})
+	if err != nil {
+		t.Fatalf("MarshalFields: %v", err)
+	}
+	for _, name := range []string{"上架日期", "负责人", "官网"} {
+		if v, ok := fields[name]; !ok || v != nil {
+			t.Errorf("%s = %#v, want null", name, v)
+		}
+	}
+	if files := fields["附件"].([]map[string]string); len(files) != 0 {
+		t.Errorf("附件 = %v, want attachments without file_token to be dropped", files)
+	}
+	if _, ok := fields["备注"]; ok {
+		t.Error("备注 is omitempty and should not be written")
+	}
+
+	type single struct {
+		File feishu.Attachment `bitable:"附件"`
+		Site feishu.Link       `bitable:"官网"`
+	}
+	fields, err = feishu.MarshalFields(single{})
+	if err != nil {
+		t.Fatalf("MarshalFields: %v", err)
+	}
+	if fields["附件"] != nil || fields["官网"] != nil {
+		t.Errorf("fields = %v, want null for zero Attachment and Link", fields)
+	}
+}
+
+func TestUnmarshalRecord(t *testing.T) {
+	// 接口返回的读取格式
+	record := &feishu.Record{
+		ID: "rec1",
+		Fields: map[string]interface{}{
+			"名称":   []interface{}{map[string]interface{}{"type": "text", "text": "苹果"}},
+			"库存":   10.0,
+			"价格":   "5.5",
+			"状态":   "在售",
+			"标签":   []interface{}{"水果", "新品"},
+			"上架日期": 1714521600000.0,
+			"是否上架": true,
+			"负责人":  []interface{}{map[string]interface{}{"id": "ou_1", "name": "张三"}},
+			"官网":   map[string]interface{}{"link": "https://example.com", "text": "官网"},
+			"附件":   []interface{}{map[string]interface{}{"file_token": "box1", "name": "a.png", "size": 1024.0}},
+			"总价": map[string]interface{}{
+				"type":  2,
+				"value": []interface{}{55.0},
+			},
+		},
+	}
+	var got product
+	if err := feishu.UnmarshalRecord(record, &got); err != nil {
+		t.Fatalf("UnmarshalRecord: %v", err)
+	}
+	want := product{
+		ID:     "rec1",
+		Name:   "苹果",
+		Stock:  10,
+		Price:  5.5,
+		Status: "在售",
+		Tags:   []string{"水果", "新品"},
+		Since:  time.UnixMilli(1714521600000),
+		OnSale: true,
+		Owner:  feishu.User{ID: "ou_1", Name: "张三"},
+		Site:   &feishu.Link{Text: "官网", URL: "https://example.com"},
+		Files:  []feishu.Attachment{{FileToken: "box1", Name: "a.png", Size: 1024}},
+		Total:  55,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UnmarshalRecord =\n%+v\nwant\n%+v", got, want)
+	}
+
+	// 写入格式可以原样读回
+	fields, err := feishu.MarshalFields(want)
+	if err != nil {
+		t.Fatalf("MarshalFields: %v", err)
+	}
+	fields["总价"] = 55.0
+	var roundTrip product
+	if err := feishu.UnmarshalFields(fields, &roundTrip); err != nil {
+		t.Fatalf("UnmarshalFields: %v", err)
+	}
+	roundTrip.ID = want.ID
+	want.Owner.Name, want.Files[0].Name, want.Files[0].Size = "", "", 0
+	if !reflect.DeepEqual(roundTrip, want) {
+		t.Errorf("round trip =\n%+v\nwant\n%+v", roundTrip, want)
+	}
+}
+
+func TestUnmarshalFieldsTypeMismatch(t *testing.T) {
+	tests := []struct {
+		field string
+		value interface{}
+	}{
+		{"库存", "很多"},
+		{"库存", 1.5},
+		{"是否上架", "是"},
+		{"标签", 3.0},
+		{"上架日期", "昨天"},
+		{"负责人", []interface{}{map[string]interface{}{"id": "ou_1"}, map[string]interface{}{"id": "ou_2"}}},
+		{"官网", "https://example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			var p product
+			err := feishu.UnmarshalFields(map[string]interface{}{tt.field: tt.value}, &p)
+			var typeErr *feishu.FieldTypeError
+			if !errors.As(err, &typeErr) || typeErr.Field != tt.field {
+				t.Errorf("err = %v, want *FieldTypeError for %s", err, tt.field)
+			}
+		})
+	}
+}
+
+func TestMarshalFieldsErrors(t *testing.T) {
+	type unsupported struct {
+		Attrs map[string]string `bitable:"属性"`
+	}
+	var typeErr *feishu.FieldTypeError
+	if _, err := feishu.MarshalFields(unsupported{}); !errors.As(err, &typeErr) || typeErr.Field != "属性" {
+		t.Errorf("unsupported type: err = %v, want *FieldTypeError", err)
+	}
+
+	type badRecordID struct {
+		ID int `bitable:",record_id"`
+	}
+	if _, err := feishu.MarshalFields(badRecordID{}); err == nil {
+		t.Error("non-string record_id: want error")
+	}
+	type duplicate struct {
+		A string `bitable:"名称"`
+		B string `bitable:"名称"`
+	}
+	if _, err := feishu.MarshalFields(duplicate{}); err == nil {
+		t.Error("duplicate field names: want error")
+	}
+	if err := feishu.UnmarshalFields(nil, product{}); err == nil {
+		t.Error("UnmarshalFields into a non-pointer: want error")
+	}
+}
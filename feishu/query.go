@@ -0,0 +1,379 @@
+package feishu
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"time"
+
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+)
+
+// Operator 筛选条件的运算符
+type Operator string
+
+// 查询记录支持的运算符
+const (
+	OpIs             Operator = "is"             // 等于
+	OpIsNot          Operator = "isNot"          // 不等于
+	OpContains       Operator = "contains"       // 包含
+	OpDoesNotContain Operator = "doesNotContain" // 不包含
+	OpIsEmpty        Operator = "isEmpty"        // 为空
+	OpIsNotEmpty     Operator = "isNotEmpty"     // 不为空
+	OpIsGreater      Operator = "isGreater"      // 大于
+	OpIsGreaterEqual Operator = "isGreaterEqual" // 大于等于
+	OpIsLess         Operator = "isLess"         // 小于
+	OpIsLessEqual    Operator = "isLessEqual"    // 小于等于
+)
+
+// 条件之间的逻辑关系
+const (
+	conjunctionAnd = "and"
+	conjunctionOr  = "or"
+)
+
+// FilterItem 筛选条件或条件组，由 Condition 和 *Filter 实现
+type FilterItem interface {
+	filterItem()
+}
+
+// Condition 单个筛选条件
+type Condition struct {
+	Field    string
+	Operator Operator
+	Values   []string
+}
+
+func (Condition) filterItem() {}
+
+// Cond 创建筛选条件。值支持 string、数字、bool 和 time.Time（按日期筛选）。
+func Cond(field string, op Operator, values ...interface{}) Condition {
+	return Condition{Field: field, Operator: op, Values: conditionValues(values)}
+}
+
+// Is 字段等于 value
+func Is(field string, value interface{}) Condition { return Cond(field, OpIs, value) }
+
+// IsNot 字段不等于 value
+func IsNot(field string, value interface{}) Condition { return Cond(field, OpIsNot, value) }
+
+// Contains 字段包含 values 中的任意一个
+func Contains(field string, values ...interface{}) Condition {
+	return Cond(field, OpContains, values...)
+}
+
+// DoesNotContain 字段不包含 values 中的任何一个
+func DoesNotContain(field string, values ...interface{}) Condition {
+	return Cond(field, OpDoesNotContain, values...)
+}
+
+// IsEmpty 字段为空
+func IsEmpty(field string) Condition { return Cond(field, OpIsEmpty) }
+
+// IsNotEmpty 字段不为空
+func IsNotEmpty(field string) Condition { return Cond(field, OpIsNotEmpty) }
+
+// IsGreater 字段大于 value
+func IsGreater(field string, value interface{}) Condition { return Cond(field, OpIsGreater, value) }
+
+// IsGreaterEqual 字段大于等于 value
+func IsGreaterEqual(field string, value interface{}) Condition {
+	return Cond(field, OpIsGreaterEqual, value)
+}
+
+// IsLess 字段小于 value
+func IsLess(field string, value interface{}) Condition { return Cond(field, OpIsLess, value) }
+
+// IsLessEqual 字段小于等于 value
+func IsLessEqual(field string, value interface{}) Condition {
+	return Cond(field, OpIsLessEqual, value)
+}
+
+// conditionValues 将条件值转换为接口要求的字符串格式，日期转换为 ["ExactDate", 毫秒时间戳]
+func conditionValues(values []interface{}) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			out = append(out, v)
+		case time.Time:
+			out = append(out, "ExactDate", strconv.FormatInt(v.UnixMilli(), 10))
+		case float64:
+			out = append(out, strconv.FormatFloat(v, 'f', -1, 64))
+		default:
+			out = append(out, fmt.Sprint(v))
+		}
+	}
+	return out
+}
+
+// Filter 由 And 或 Or 创建的条件组。
+// 飞书只支持两层嵌套：顶层条件组可以包含子条件组，子条件组只能包含条件。
+type Filter struct {
+	conjunction string
+	items       []FilterItem
+}
+
+func (*Filter) filterItem() {}
+
+// And 所有条件都满足
+func And(items ...FilterItem) *Filter {
+	return &Filter{conjunction: conjunctionAnd, items: items}
+}
+
+// Or 任意一个条件满足
+func Or(items ...FilterItem) *Filter {
+	return &Filter{conjunction: conjunctionOr, items: items}
+}
+
+// build 转换为 SDK 的筛选条件
+func (f *Filter) build() (*larkbitable.FilterInfo, error) {
+	info := &larkbitable.FilterInfo{Conjunction: &f.conjunction}
+	for _, item := range f.items {
+		switch item := item.(type) {
+		case Condition:
+			cond, err := item.build()
+			if err != nil {
+				return nil, err
+			}
+			info.Conditions = append(info.Conditions, cond)
+		case *Filter:
+			child := &larkbitable.ChildrenFilter{Conjunction: &item.conjunction}
+			for _, sub := range item.items {
+				cond, ok := sub.(Condition)
+				if !ok {
+					return nil, errors.New("条件组最多嵌套两层")
+				}
+				c, err := cond.build()
+				if err != nil {
+					return nil, err
+				}
+				child.Conditions = append(child.Conditions, c)
+			}
+			info.Children = append(info.Children, child)
+		}
+	}
+	return info, nil
+}
+
+// build 转换为 SDK 的筛选条件
+func (c Condition) build() (*larkbitable.Condition, error) {
+	if c.Field == "" {
+		return nil, errors.New("筛选条件缺少字段名")
+	}
+	if c.Operator == "" {
+		return nil, fmt.Errorf("字段 %s 的筛选条件缺少运算符", c.Field)
+	}
+	field, op := c.Field, string(c.Operator)
+	return &larkbitable.Condition{FieldName: &field, Operator: &op, Value: c.Values}, nil
+}
+
+// Query 查询记录的条件：筛选、排序、返回字段和视图。
+// 零值（或 nil）表示查询所有记录。
+//
+//	q := feishu.NewQuery().
+//		Where(feishu.Is("状态", "在售"), feishu.IsLess("库存数量", 10)).
+//		OrderBy("创建时间", true)
+type Query struct {
+	filter *Filter
+	sorts  []*larkbitable.Sort
+	fields []string
+	viewID string
+}
+
+// NewQuery 创建查询
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Filter 设置筛选条件，覆盖之前设置的条件
+func (q *Query) Filter(f *Filter) *Query {
+	q.filter = f
+	return q
+}
+
+// Where 设置筛选条件，所有条件都需满足，等价于 Filter(And(items...))
+func (q *Query) Where(items ...FilterItem) *Query {
+	return q.Filter(And(items...))
+}
+
+// OrderBy 追加排序字段，desc 为 true 时倒序
+func (q *Query) OrderBy(field string, desc bool) *Query {
+	q.sorts = append(q.sorts, &larkbitable.Sort{FieldName: &field, Desc: &desc})
+	return q
+}
+
+// Select 只返回指定的字段
+func (q *Query) Select(fields ...string) *Query {
+	q.fields = fields
+	return q
+}
+
+// View 只查询指定视图中的记录，记录顺序与视图一致（设置了 OrderBy 时以 OrderBy 为准）
+func (q *Query) View(viewID string) *Query {
+	q.viewID = viewID
+	return q
+}
+
+// build 转换为 SDK 的请求体
+func (q *Query) build() (*larkbitable.SearchAppTableRecordReqBody, error) {
+	builder := larkbitable.NewSearchAppTableRecordReqBodyBuilder().AutomaticFields(true)
+	if q == nil {
+		return builder.Build(), nil
+	}
+	if q.filter != nil && len(q.filter.items) > 0 {
+		filter, err := q.filter.build()
+		if err != nil {
+			return nil, fmt.Errorf("查询条件无效: %w", err)
+		}
+		builder.Filter(filter)
+	}
+	if len(q.sorts) > 0 {
+		builder.Sort(q.sorts)
+	}
+	if len(q.fields) > 0 {
+		builder.FieldNames(q.fields)
+	}
+	if q.viewID != "" {
+		builder.ViewId(q.viewID)
+	}
+	return builder.Build(), nil
+}
+
+// SearchRecords 按查询条件查询一页记录，q 为 nil 时查询所有记录。
+// 返回：记录列表、下一页token、是否有更多、错误。
+func (c *MultiTableClient) SearchRecords(appToken, tableID string, q *Query, pageSize int, pageToken string) ([]*Record, string, bool, error) {
+	return c.SearchRecordsContext(context.Background(), appToken, tableID, q, pageSize, pageToken)
+}
+
+// SearchRecordsContext 按查询条件查询一页记录（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) SearchRecordsContext(ctx context.Context, appToken, tableID string, q *Query, pageSize int, pageToken string) ([]*Record, string, bool, error) {
+	body, err := q.build()
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	reqBuilder := larkbitable.NewSearchAppTableRecordReqBuilder().
+		AppToken(appToken).
+		TableId(tableID).
+		PageSize(pageSize).
+		Body(body)
+
+	if pageToken != "" {
+		reqBuilder = reqBuilder.PageToken(pageToken)
+	}
+
+	req := reqBuilder.Build()
+
+	var resp *larkbitable.SearchAppTableRecordResp
+	err = c.do(ctx, EndpointRecordRead, true, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTableRecord.Search(ctx, req)
+		if err != nil {
+			return fmt.Errorf("搜索记录失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("搜索记录", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	items := make([]*Record, 0, len(resp.Data.Items))
+	for _, item := range resp.Data.Items {
+		items = append(items, newRecord(item))
+	}
+
+	nextPageToken := ""
+	if resp.Data.PageToken != nil {
+		nextPageToken = *resp.Data.PageToken
+	}
+
+	hasMore := resp.Data.HasMore != nil && *resp.Data.HasMore
+
+	return items, nextPageToken, hasMore, nil
+}
+
+// QueryRecords 返回遍历满足查询条件的所有记录的迭代器，自动翻页。
+// pageSize 和错误处理与 AllRecords 相同。
+//
+//	q := feishu.NewQuery().Where(feishu.Is("状态", "在售"))
+//	for record, err := range client.QueryRecords(appToken, tableID, q, 0) {
+//		...
+//	}
+func (c *MultiTableClient) QueryRecords(appToken, tableID string, q *Query, pageSize int) iter.Seq2[*Record, error] {
+	return c.QueryRecordsContext(context.Background(), appToken, tableID, q, pageSize)
+}
+
+// QueryRecordsContext 返回遍历满足查询条件的记录的迭代器（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) QueryRecordsContext(ctx context.Context, appToken, tableID string, q *Query, pageSize int) iter.Seq2[*Record, error] {
+	return paginate(pageSize, func(pageSize int, pageToken string) ([]*Record, string, bool, error) {
+		return c.SearchRecordsContext(ctx, appToken, tableID, q, pageSize, pageToken)
+	})
+}
+
+// DeleteWhereOptions DeleteWhere 的可选参数
+type DeleteWhereOptions struct {
+	// Backup 不为 nil 时，删除前将匹配的记录以 NDJSON 格式（每行一条 Record 的 JSON）写入
+	Backup io.Writer
+	// Confirm 不为 nil 时，在查询出匹配的记录后、删除前调用，返回 false 则不删除
+	Confirm func(records []*Record) bool
+}
+
+// DeleteWhere 删除满足查询条件的所有记录，返回删除的记录数。q 为 nil 时删除数据表中的所有记录。
+// 先查询出全部匹配的记录，再按 MaxBatchSize 分批删除；部分批次失败时返回 *BatchError，
+// 此时返回值为实际删除的记录数。
+func (c *MultiTableClient) DeleteWhere(appToken, tableID string, q *Query, opts DeleteWhereOptions) (int, error) {
+	return c.DeleteWhereContext(context.Background(), appToken, tableID, q, opts)
+}
+
+// DeleteWhereContext 删除满足查询条件的所有记录（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) DeleteWhereContext(ctx context.Context, appToken, tableID string, q *Query, opts DeleteWhereOptions) (int, error) {
+	var records []*Record
+	for record, err := range c.QueryRecordsContext(ctx, appToken, tableID, q, MaxPageSize) {
+		if err != nil {
+			return 0, err
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return 0, nil
+	}
+	if opts.Confirm != nil && !opts.Confirm(records) {
+		return 0, nil
+	}
+
+	if opts.Backup != nil {
+		enc := json.NewEncoder(opts.Backup)
+		enc.SetEscapeHTML(false)
+		for _, record := range records {
+			if err := enc.Encode(record); err != nil {
+				return 0, fmt.Errorf("备份记录失败: %w", err)
+			}
+		}
+	}
+
+	recordIDs := make([]string, 0, len(records))
+	for _, record := range records {
+		recordIDs = append(recordIDs, record.ID)
+	}
+	err := c.BatchDeleteRecordsContext(ctx, appToken, tableID, recordIDs)
+	var batchErr *BatchError
+	if errors.As(err, &batchErr) {
+		deleted := len(recordIDs)
+		for _, chunk := range batchErr.Chunks {
+			deleted -= chunk.End - chunk.Start
+		}
+		return deleted, err
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(recordIDs), nil
+}
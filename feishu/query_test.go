@@ -0,0 +1,273 @@
+package feishu_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"feishu_bitable_demo/feishu"
+	"feishu_bitable_demo/feishu/fakeserver"
+)
+
+// failingWriter 写入总是失败，用于测试备份失败时不删除记录
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestDeleteWhere(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         *feishu.Query
+		confirm       *bool // nil 表示不设置 Confirm
+		backup        bool
+		wantDeleted   int
+		wantRemaining int
+		wantConfirmed int // Confirm 收到的记录数
+	}{
+		{name: "全部删除", query: nil, wantDeleted: 10},
+		{name: "按条件删除", query: feishu.NewQuery().Where(feishu.IsGreater("数量", 7)), wantDeleted: 3, wantRemaining: 7},
+		{name: "没有匹配的记录", query: feishu.NewQuery().Where(feishu.IsGreater("数量", 100)), confirm: ptr(true), wantRemaining: 10},
+		{name: "取消删除", query: feishu.NewQuery().Where(feishu.IsLessEqual("数量", 4)), confirm: ptr(false), wantRemaining: 10, wantConfirmed: 4},
+		{name: "确认并备份", query: feishu.NewQuery().Where(feishu.IsLessEqual("数量", 4)), confirm: ptr(true), backup: true, wantDeleted: 4, wantRemaining: 6, wantConfirmed: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, appToken, tableID := newTestTable(t)
+			if _, err := client.BatchCreateRecords(appToken, tableID, newRecords(10)); err != nil {
+				t.Fatalf("BatchCreateRecords: %v", err)
+			}
+
+			var (
+				opts      feishu.DeleteWhereOptions
+				confirmed int
+				backup    bytes.Buffer
+			)
+			if tt.confirm != nil {
+				opts.Confirm = func(records []*feishu.Record) bool {
+					confirmed = len(records)
+					return *tt.confirm
+				}
+			}
+			if tt.backup {
+				opts.Backup = &backup
+			}
+
+			deleted, err := client.DeleteWhere(appToken, tableID, tt.query, opts)
+			if err != nil {
+				t.Fatalf("DeleteWhere: %v", err)
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("deleted = %d, want %d", deleted, tt.wantDeleted)
+			}
+			if n := len(srv.Records(appToken, tableID)); n != tt.wantRemaining {
+				t.Errorf("%d records remaining, want %d", n, tt.wantRemaining)
+			}
+			if confirmed != tt.wantConfirmed {
+				t.Errorf("Confirm got %d records, want %d", confirmed, tt.wantConfirmed)
+			}
+			if tt.backup {
+				var lines int
+				scanner := bufio.NewScanner(&backup)
+				for scanner.Scan() {
+					var record feishu.Record
+					if err := json.Unmarshal(scanner.Bytes(), &record); err != nil || record.ID == "" || record.Fields["数量"] == nil {
+						t.Errorf("backup line %q: %v, want a record with record_id and fields", scanner.Text(), err)
+					}
+					lines++
+				}
+				if lines != tt.wantDeleted {
+					t.Errorf("backup has %d lines, want %d", lines, tt.wantDeleted)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteWhereBackupFailure(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t)
+	if _, err := client.BatchCreateRecords(appToken, tableID, newRecords(3)); err != nil {
+		t.Fatalf("BatchCreateRecords: %v", err)
+	}
+	deleted, err := client.DeleteWhere(appToken, tableID, nil, feishu.DeleteWhereOptions{Backup: failingWriter{}})
+	if err == nil || deleted != 0 {
+		t.Fatalf("DeleteWhere = %d, %v; want an error and nothing deleted", deleted, err)
+	}
+	if n := len(srv.Records(appToken, tableID)); n != 3 {
+		t.Errorf("%d records remaining, want 3", n)
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// searchRequests 返回模拟服务收到的记录查询请求
+func searchRequests(srv *fakeserver.Server) []fakeserver.Request {
+	var out []fakeserver.Request
+	for _, r := range srv.Requests() {
+		if strings.HasSuffix(r.Path, "/records/search") {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// jsonEqual 比较两段 JSON 是否表示相同的值
+func jsonEqual(t *testing.T, got []byte, want string) bool {
+	t.Helper()
+	var g, w interface{}
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("invalid JSON %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("invalid JSON %s: %v", want, err)
+	}
+	return reflect.DeepEqual(g, w)
+}
+
+func TestQueryRequestBody(t *testing.T) {
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		query *feishu.Query
+		view  bool // 查询数据表的默认视图，want 中的 {view} 替换为其 view_id
+		want  string
+	}{
+		{name: "nil", query: nil, want: `{"automatic_fields":true}`},
+		{name: "没有条件", query: feishu.NewQuery(), want: `{"automatic_fields":true}`},
+		{name: "空条件组", query: feishu.NewQuery().Where(), want: `{"automatic_fields":true}`},
+		{
+			name:  "Where",
+			query: feishu.NewQuery().Where(feishu.Is("名称", "记录1"), feishu.IsLess("数量", 10), feishu.IsNotEmpty("名称")),
+			want: `{"automatic_fields":true,"filter":{"conjunction":"and","conditions":[
+				{"field_name":"名称","operator":"is","value":["记录1"]},
+				{"field_name":"数量","operator":"isLess","value":["10"]},
+				{"field_name":"名称","operator":"isNotEmpty"}]}}`,
+		},
+		{
+			name: "嵌套条件组",
+			query: feishu.NewQuery().Filter(feishu.Or(
+				feishu.IsGreater("数量", 8.5),
+				feishu.And(feishu.Contains("名称", "记录", "产品"), feishu.IsLessEqual("数量", 2)),
+			)),
+			want: `{"automatic_fields":true,"filter":{"conjunction":"or",
+				"conditions":[{"field_name":"数量","operator":"isGreater","value":["8.5"]}],
+				"children":[{"conjunction":"and","conditions":[
+					{"field_name":"名称","operator":"contains","value":["记录","产品"]},
+					{"field_name":"数量","operator":"isLessEqual","value":["2"]}]}]}}`,
+		},
+		{
+			name:  "日期",
+			query: feishu.NewQuery().Where(feishu.IsGreaterEqual("数量", date)),
+			want: `{"automatic_fields":true,"filter":{"conjunction":"and","conditions":[
+				{"field_name":"数量","operator":"isGreaterEqual","value":["ExactDate","1714521600000"]}]}}`,
+		},
+		{
+			name:  "排序、返回字段和视图",
+			query: feishu.NewQuery().OrderBy("数量", true).OrderBy("名称", false).Select("名称"),
+			view:  true,
+			want: `{"automatic_fields":true,"view_id":"{view}","field_names":["名称"],
+				"sort":[{"field_name":"数量","desc":true},{"field_name":"名称","desc":false}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, appToken, tableID := newTestTable(t)
+			views, err := client.ListViews(appToken, tableID)
+			if err != nil {
+				t.Fatalf("ListViews: %v", err)
+			}
+			if tt.view {
+				tt.query.View(views[0].ID)
+			}
+
+			if _, _, _, err := client.SearchRecords(appToken, tableID, tt.query, 10, ""); err != nil {
+				t.Fatalf("SearchRecords: %v", err)
+			}
+			requests := searchRequests(srv)
+			if len(requests) != 1 {
+				t.Fatalf("sent %d search requests, want 1", len(requests))
+			}
+			want := strings.ReplaceAll(tt.want, "{view}", views[0].ID)
+			if !jsonEqual(t, requests[0].Body, want) {
+				t.Errorf("request body = %s, want %s", requests[0].Body, want)
+			}
+		})
+	}
+}
+
+func TestQueryInvalidFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *feishu.Query
+		want  string
+	}{
+		{
+			name:  "嵌套超过两层",
+			query: feishu.NewQuery().Where(feishu.Or(feishu.Is("名称", "a"), feishu.And(feishu.Is("名称", "b")))),
+			want:  "最多嵌套两层",
+		},
+		{name: "缺少字段名", query: feishu.NewQuery().Where(feishu.Is("", "a")), want: "缺少字段名"},
+		{name: "缺少运算符", query: feishu.NewQuery().Where(feishu.Condition{Field: "名称"}), want: "缺少运算符"},
+		{
+			name:  "子条件组中的条件无效",
+			query: feishu.NewQuery().Where(feishu.Or(feishu.Condition{Field: "名称"})),
+			want:  "缺少运算符",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, appToken, tableID := newTestTable(t)
+			before := srv.RequestCount()
+			_, _, _, err := client.SearchRecords(appToken, tableID, tt.query, 10, "")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("SearchRecords err = %v, want it to contain %q", err, tt.want)
+			}
+			if n := srv.RequestCount() - before; n != 0 {
+				t.Errorf("sent %d requests for an invalid query, want 0", n)
+			}
+		})
+	}
+}
+
+func TestQueryRecordsPagination(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t)
+	if _, err := client.BatchCreateRecords(appToken, tableID, newRecords(10)); err != nil {
+		t.Fatalf("BatchCreateRecords: %v", err)
+	}
+
+	q := feishu.NewQuery().Where(feishu.IsGreater("数量", 2)).OrderBy("数量", true).Select("数量")
+	var got []float64
+	for record, err := range client.QueryRecords(appToken, tableID, q, 3) {
+		if err != nil {
+			t.Fatalf("QueryRecords: %v", err)
+		}
+		n, _ := feishu.AsNumber(record.Fields["数量"])
+		got = append(got, n)
+		if _, ok := record.Fields["名称"]; ok {
+			t.Errorf("record %s has 名称, want only the selected fields", record.ID)
+		}
+	}
+	if want := []float64{10, 9, 8, 7, 6, 5, 4, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("records = %v, want %v", got, want)
+	}
+
+	requests := searchRequests(srv)
+	if len(requests) != 3 {
+		t.Fatalf("sent %d search requests, want 3 pages of 3", len(requests))
+	}
+	for i, r := range requests {
+		if size := r.Query.Get("page_size"); size != "3" {
+			t.Errorf("request %d: page_size = %q, want 3", i, size)
+		}
+		if token := r.Query.Get("page_token"); (token == "") != (i == 0) {
+			t.Errorf("request %d: page_token = %q, want it set on every page but the first", i, token)
+		}
+		if !bytes.Equal(r.Body, requests[0].Body) {
+			t.Errorf("request %d body = %s, want the same query on every page", i, r.Body)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package feishu
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Endpoint 接口类别，飞书对不同类别的接口有各自的频率限制
+type Endpoint string
+
+const (
+	EndpointRecordRead  Endpoint = "record_read"  // 查询记录
+	EndpointRecordWrite Endpoint = "record_write" // 新增、更新、删除记录
+	EndpointDocxBlock   Endpoint = "docx_block"   // 创建云文档块
+	EndpointDefault     Endpoint = "default"      // 其他接口
+)
+
+// RateLimit 单个接口类别的限流配置
+type RateLimit struct {
+	QPS   float64 `yaml:"qps"`   // 每秒请求数，小于等于 0 表示不限流
+	Burst int     `yaml:"burst"` // 允许的突发请求数，默认为 1
+}
+
+// DefaultRateLimits 默认限流配置，参考飞书开放平台各接口的频率限制
+var DefaultRateLimits = map[Endpoint]RateLimit{
+	EndpointRecordRead:  {QPS: 20, Burst: 20},
+	EndpointRecordWrite: {QPS: 10, Burst: 10},
+	EndpointDocxBlock:   {QPS: 3, Burst: 1},
+}
+
+// WithRateLimits 覆盖指定接口类别的限流配置，未指定的类别沿用 DefaultRateLimits
+func WithRateLimits(limits map[Endpoint]RateLimit) Option {
+	return func(o *clientOptions) {
+		for endpoint, limit := range limits {
+			o.rateLimits[endpoint] = limit
+		}
+	}
+}
+
+// WithoutRateLimit 关闭客户端限流
+func WithoutRateLimit() Option {
+	return func(o *clientOptions) {
+		o.rateLimits = map[Endpoint]RateLimit{}
+	}
+}
+
+// rateLimiter 按接口类别划分的令牌桶集合，创建后只读，可在多个 goroutine 间共享
+type rateLimiter struct {
+	buckets map[Endpoint]*tokenBucket
+}
+
+func newRateLimiter(limits map[Endpoint]RateLimit) *rateLimiter {
+	l := &rateLimiter{buckets: make(map[Endpoint]*tokenBucket)}
+	for endpoint, limit := range limits {
+		if limit.QPS <= 0 {
+			continue
+		}
+		l.buckets[endpoint] = newTokenBucket(limit)
+	}
+	return l
+}
+
+// wait 阻塞直到该类别允许发出下一个请求，或 ctx 被取消
+func (l *rateLimiter) wait(ctx context.Context, endpoint Endpoint) error {
+	if l == nil {
+		return nil
+	}
+	bucket, ok := l.buckets[endpoint]
+	if !ok {
+		return nil
+	}
+	return bucket.wait(ctx)
+}
+
+// tokenBucket 令牌桶
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // 每秒补充的令牌数
+	burst  float64
+	tokens float64 // 当前令牌数，为负数时表示已预约的等待量
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   limit.QPS,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// wait 预约一个令牌，令牌不足时等待到预约时刻
+func (b *tokenBucket) wait(ctx context.Context) error {
+	delay := b.reserve(time.Now())
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		b.refund()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve 在 now 时刻补充令牌后取走一个，返回需要等待的时间
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// refund 归还预约后未使用的令牌
+func (b *tokenBucket) refund() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens++
+}
@@ -0,0 +1,146 @@
+package feishu
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newTokenBucket(RateLimit{QPS: 10, Burst: 3})
+	b.last = start
+
+	steps := []struct {
+		at   time.Duration // 相对 start 的时刻
+		want time.Duration // 需要等待的时间
+	}{
+		// 突发：前 3 个请求不需要等待
+		{0, 0}, {0, 0}, {0, 0},
+		// 之后每个请求按 10 QPS 排队
+		{0, 100 * time.Millisecond},
+		{0, 200 * time.Millisecond},
+		{50 * time.Millisecond, 250 * time.Millisecond},
+		// 空闲足够久后最多积累 burst 个令牌
+		{10 * time.Second, 0}, {10 * time.Second, 0}, {10 * time.Second, 0},
+		{10 * time.Second, 100 * time.Millisecond},
+	}
+	for i, step := range steps {
+		if got := b.reserve(start.Add(step.at)); !approxDuration(got, step.want) {
+			t.Errorf("step %d: reserve at +%v = %v, want %v", i, step.at, got, step.want)
+		}
+	}
+}
+
+func TestTokenBucketBurstDefault(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(RateLimit{QPS: 2})
+	b.last = now
+	if got := b.reserve(now); got != 0 {
+		t.Errorf("first reserve = %v, want 0", got)
+	}
+	if got := b.reserve(now); !approxDuration(got, 500*time.Millisecond) {
+		t.Errorf("second reserve = %v, want 500ms (burst defaults to 1)", got)
+	}
+}
+
+func TestTokenBucketWaitCanceled(t *testing.T) {
+	b := newTokenBucket(RateLimit{QPS: 1, Burst: 1})
+	if err := b.wait(context.Background()); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := b.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("wait err = %v, want context.DeadlineExceeded", err)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := b.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("wait err = %v, want context.Canceled", err)
+	}
+
+	// 两次取消的等待都归还了令牌，下一个请求只需等待第一个令牌补充完
+	if got := b.reserve(time.Now()); got > time.Second || got < 900*time.Millisecond {
+		t.Errorf("reserve after canceled waits = %v, want just under 1s", got)
+	}
+}
+
+func TestRateLimiterEndpoints(t *testing.T) {
+	l := newRateLimiter(map[Endpoint]RateLimit{
+		EndpointRecordRead:  {QPS: 1, Burst: 1},
+		EndpointRecordWrite: {QPS: 1, Burst: 2},
+		EndpointDocxBlock:   {QPS: 0, Burst: 5}, // 不限流
+	})
+	if _, ok := l.buckets[EndpointDocxBlock]; ok {
+		t.Error("QPS 0 created a bucket, want the endpoint to be unlimited")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := l.wait(ctx, EndpointRecordRead); err != nil {
+		t.Fatalf("first read: %v", err)
+	}
+	// 读接口的令牌用完不影响其他类别
+	for i := 0; i < 2; i++ {
+		if err := l.wait(ctx, EndpointRecordWrite); err != nil {
+			t.Fatalf("write %d: %v", i, err)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		if err := l.wait(ctx, EndpointDocxBlock); err != nil {
+			t.Fatalf("docx %d: %v", i, err)
+		}
+		if err := l.wait(ctx, EndpointDefault); err != nil {
+			t.Fatalf("default %d: %v", i, err)
+		}
+	}
+	if err := l.wait(ctx, EndpointRecordRead); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("second read err = %v, want it to wait past the deadline", err)
+	}
+
+	var nilLimiter *rateLimiter
+	if err := nilLimiter.wait(ctx, EndpointRecordRead); err != nil {
+		t.Errorf("nil limiter: %v", err)
+	}
+}
+
+func TestDefaultRateLimits(t *testing.T) {
+	buckets := NewMultiTableClient("cli_test", "secret").limiter.buckets
+	for _, endpoint := range []Endpoint{EndpointRecordRead, EndpointRecordWrite, EndpointDocxBlock} {
+		b, ok := buckets[endpoint]
+		if !ok {
+			t.Errorf("no bucket for %s", endpoint)
+			continue
+		}
+		if want := DefaultRateLimits[endpoint]; b.rate != want.QPS {
+			t.Errorf("%s rate = %v, want %v", endpoint, b.rate, want.QPS)
+		}
+	}
+	if _, ok := buckets[EndpointDefault]; ok {
+		t.Error("default endpoint is rate limited, want no limit")
+	}
+
+	buckets = NewMultiTableClient("cli_test", "secret", WithRateLimits(map[Endpoint]RateLimit{EndpointRecordRead: {QPS: 5}})).limiter.buckets
+	if got := buckets[EndpointRecordRead].rate; got != 5 {
+		t.Errorf("WithRateLimits: record_read rate = %v, want 5", got)
+	}
+	if got := buckets[EndpointRecordWrite].rate; got != DefaultRateLimits[EndpointRecordWrite].QPS {
+		t.Errorf("WithRateLimits: record_write rate = %v, want the default kept", got)
+	}
+	if DefaultRateLimits[EndpointRecordRead].QPS == 5 {
+		t.Error("WithRateLimits modified DefaultRateLimits")
+	}
+
+	if buckets := NewMultiTableClient("cli_test", "secret", WithoutRateLimit()).limiter.buckets; len(buckets) != 0 {
+		t.Errorf("WithoutRateLimit left %d buckets", len(buckets))
+	}
+}
+
+// approxDuration 比较时长，允许浮点运算带来的微小误差
+func approxDuration(got, want time.Duration) bool {
+	d := got - want
+	return d > -time.Microsecond && d < time.Microsecond
+}
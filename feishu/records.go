@@ -3,34 +3,74 @@ package feishu
 import (
 	"context"
 	"fmt"
+	"iter"
+	"time"
 
 	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
 )
 
-// CreateRecord 创建单个记录
-func (c *MultiTableClient) CreateRecord(appToken, tableID string, fields map[string]interface{}) (string, error) {
+// CreateRecord 创建单个记录，返回创建后的记录（含 record_id）。
+// 请求携带 client_token，自动重试不会重复创建，见 WithClientToken。
+func (c *MultiTableClient) CreateRecord(appToken, tableID string, fields map[string]interface{}) (*Record, error) {
+	return c.CreateRecordContext(context.Background(), appToken, tableID, fields)
+}
+
+// CreateRecordContext 创建单个记录（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) CreateRecordContext(ctx context.Context, appToken, tableID string, fields map[string]interface{}) (*Record, error) {
 	req := larkbitable.NewCreateAppTableRecordReqBuilder().
 		AppToken(appToken).
 		TableId(tableID).
+		ClientToken(clientToken(ctx)).
 		AppTableRecord(larkbitable.NewAppTableRecordBuilder().
 			Fields(fields).
 			Build()).
 		Build()
 
-	resp, err := c.client.Bitable.AppTableRecord.Create(context.Background(), req)
+	var resp *larkbitable.CreateAppTableRecordResp
+	err := c.do(ctx, EndpointRecordWrite, true, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTableRecord.Create(ctx, req)
+		if err != nil {
+			return fmt.Errorf("创建记录失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("创建记录", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("创建记录失败: %v", err)
+		return nil, err
 	}
 
-	if !resp.Success() {
-		return "", fmt.Errorf("创建记录失败 [code=%d]: %s", resp.Code, resp.Msg)
-	}
+	return newRecord(resp.Data.Record), nil
+}
 
-	return *resp.Data.Record.RecordId, nil
+// BatchCreateRecords 批量创建记录，结果中的记录与 records 顺序一致。
+// 超过 MaxBatchSize 条时自动分批请求，部分批次失败时其余批次照常写入，
+// 返回的 BatchResult 中列出每条失败的记录及原因，同时返回 *BatchError。
+// 每批请求携带各自的 client_token，自动重试不会重复创建，见 WithClientToken。
+func (c *MultiTableClient) BatchCreateRecords(appToken, tableID string, records []CreateRecordRequest) (*BatchResult, error) {
+	return c.BatchCreateRecordsContext(context.Background(), appToken, tableID, records)
 }
 
-// BatchCreateRecords 批量创建记录
-func (c *MultiTableClient) BatchCreateRecords(appToken, tableID string, records []CreateRecordRequest) ([]string, error) {
+// BatchCreateRecordsContext 批量创建记录（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) BatchCreateRecordsContext(ctx context.Context, appToken, tableID string, records []CreateRecordRequest) (*BatchResult, error) {
+	token := clientToken(ctx)
+	result := &BatchResult{Records: make([]*Record, len(records))}
+	err := c.runChunks(ctx, "批量创建记录", len(records), func(start, end int) error {
+		chunk, err := c.batchCreateRecords(ctx, appToken, tableID, chunkClientToken(token, start), records[start:end])
+		if err != nil {
+			return err
+		}
+		copy(result.Records[start:end], chunk)
+		return nil
+	})
+	result.addFailures(err, func(int) string { return "" })
+	return result, err
+}
+
+// batchCreateRecords 以单次请求批量创建记录，records 不能超过 MaxBatchSize 条
+func (c *MultiTableClient) batchCreateRecords(ctx context.Context, appToken, tableID, clientToken string, records []CreateRecordRequest) ([]*Record, error) {
 	// 转换为官方 SDK 格式
 	larkRecords := make([]*larkbitable.AppTableRecord, 0, len(records))
 	for _, record := range records {
@@ -42,30 +82,43 @@ func (c *MultiTableClient) BatchCreateRecords(appToken, tableID string, records
 	req := larkbitable.NewBatchCreateAppTableRecordReqBuilder().
 		AppToken(appToken).
 		TableId(tableID).
+		ClientToken(clientToken).
 		Body(larkbitable.NewBatchCreateAppTableRecordReqBodyBuilder().
 			Records(larkRecords).
 			Build()).
 		Build()
 
-	resp, err := c.client.Bitable.AppTableRecord.BatchCreate(context.Background(), req)
+	var resp *larkbitable.BatchCreateAppTableRecordResp
+	err := c.do(ctx, EndpointRecordWrite, true, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTableRecord.BatchCreate(ctx, req)
+		if err != nil {
+			return fmt.Errorf("批量创建记录失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("批量创建记录", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("批量创建记录失败: %v", err)
-	}
-
-	if !resp.Success() {
-		return nil, fmt.Errorf("批量创建记录失败 [code=%d]: %s", resp.Code, resp.Msg)
+		return nil, err
 	}
 
-	recordIDs := make([]string, 0, len(resp.Data.Records))
+	created := make([]*Record, 0, len(resp.Data.Records))
 	for _, record := range resp.Data.Records {
-		recordIDs = append(recordIDs, *record.RecordId)
+		created = append(created, newRecord(record))
 	}
 
-	return recordIDs, nil
+	return created, nil
 }
 
 // UpdateRecord 更新记录
 func (c *MultiTableClient) UpdateRecord(appToken, tableID, recordID string, fields map[string]interface{}) error {
+	return c.UpdateRecordContext(context.Background(), appToken, tableID, recordID, fields)
+}
+
+// UpdateRecordContext 更新记录（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) UpdateRecordContext(ctx context.Context, appToken, tableID, recordID string, fields map[string]interface{}) error {
 	req := larkbitable.NewUpdateAppTableRecordReqBuilder().
 		AppToken(appToken).
 		TableId(tableID).
@@ -75,23 +128,105 @@ func (c *MultiTableClient) UpdateRecord(appToken, tableID, recordID string, fiel
 			Build()).
 		Build()
 
-	resp, err := c.client.Bitable.AppTableRecord.Update(context.Background(), req)
+	var resp *larkbitable.UpdateAppTableRecordResp
+	err := c.do(ctx, EndpointRecordWrite, true, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTableRecord.Update(ctx, req)
+		if err != nil {
+			return fmt.Errorf("更新记录失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("更新记录", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("更新记录失败: %v", err)
+		return err
 	}
 
-	if !resp.Success() {
-		return fmt.Errorf("更新记录失败 [code=%d]: %s", resp.Code, resp.Msg)
+	return nil
+}
+
+// UpdateCondition 条件更新的检查条件，未设置的条件不检查
+type UpdateCondition struct {
+	// UnmodifiedSince 记录的最后修改时间晚于该时间时视为冲突，通常取读取记录时的 Record.LastModifiedTime
+	UnmodifiedSince time.Time
+	// VersionField 不为空时，要求记录中该字段的当前值等于 Version。
+	// 使用版本字段时，调用方需要在 fields 中同时写入新的版本号。
+	VersionField string
+	Version      interface{}
+}
+
+// IfUnmodified 返回要求记录自读取后未被修改的条件
+func IfUnmodified(record *Record) UpdateCondition {
+	return UpdateCondition{UnmodifiedSince: record.LastModifiedTime}
+}
+
+// UpdateRecordIfUnmodified 先读取记录并检查 cond，满足时再更新，否则返回 *ConflictError。
+// 飞书没有原生的条件更新接口，检查与写入之间仍有很短的时间窗口，只能降低而不能完全避免覆盖。
+func (c *MultiTableClient) UpdateRecordIfUnmodified(appToken, tableID, recordID string, cond UpdateCondition, fields map[string]interface{}) error {
+	return c.UpdateRecordIfUnmodifiedContext(context.Background(), appToken, tableID, recordID, cond, fields)
+}
+
+// UpdateRecordIfUnmodifiedContext 条件更新记录（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) UpdateRecordIfUnmodifiedContext(ctx context.Context, appToken, tableID, recordID string, cond UpdateCondition, fields map[string]interface{}) error {
+	current, err := c.GetRecordContext(ctx, appToken, tableID, recordID)
+	if err != nil {
+		return err
+	}
+	if err := cond.check(current); err != nil {
+		return err
 	}
+	return c.UpdateRecordContext(ctx, appToken, tableID, recordID, fields)
+}
 
+// check 检查记录的当前内容是否满足条件
+func (cond UpdateCondition) check(current *Record) error {
+	if !cond.UnmodifiedSince.IsZero() && current.LastModifiedTime.After(cond.UnmodifiedSince) {
+		return &ConflictError{
+			RecordID: current.ID,
+			Reason: fmt.Sprintf("最后修改时间 %s 晚于 %s",
+				current.LastModifiedTime.Format(time.RFC3339Nano), cond.UnmodifiedSince.Format(time.RFC3339Nano)),
+			Current: current,
+		}
+	}
+	if cond.VersionField != "" {
+		got, want := comparableValue(current.Fields[cond.VersionField]), comparableValue(cond.Version)
+		if got != want {
+			return &ConflictError{
+				RecordID: current.ID,
+				Reason:   fmt.Sprintf("字段 %s 的值为 %q，期望为 %q", cond.VersionField, got, want),
+				Current:  current,
+			}
+		}
+	}
 	return nil
 }
 
-// BatchUpdateRecords 批量更新记录
-func (c *MultiTableClient) BatchUpdateRecords(appToken, tableID string, records []struct {
-	RecordID string
-	Fields   map[string]interface{}
-}) error {
+// BatchUpdateRecords 批量更新记录，结果中的记录与 records 顺序一致。
+// 超过 MaxBatchSize 条时自动分批请求，部分批次失败时其余批次照常写入，
+// 返回的 BatchResult 中列出每条失败的记录及原因，同时返回 *BatchError。
+func (c *MultiTableClient) BatchUpdateRecords(appToken, tableID string, records []UpdateRecordRequest) (*BatchResult, error) {
+	return c.BatchUpdateRecordsContext(context.Background(), appToken, tableID, records)
+}
+
+// BatchUpdateRecordsContext 批量更新记录（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) BatchUpdateRecordsContext(ctx context.Context, appToken, tableID string, records []UpdateRecordRequest) (*BatchResult, error) {
+	result := &BatchResult{Records: make([]*Record, len(records))}
+	err := c.runChunks(ctx, "批量更新记录", len(records), func(start, end int) error {
+		chunk, err := c.batchUpdateRecords(ctx, appToken, tableID, records[start:end])
+		if err != nil {
+			return err
+		}
+		copy(result.Records[start:end], chunk)
+		return nil
+	})
+	result.addFailures(err, func(i int) string { return records[i].RecordID })
+	return result, err
+}
+
+// batchUpdateRecords 以单次请求批量更新记录，records 不能超过 MaxBatchSize 条
+func (c *MultiTableClient) batchUpdateRecords(ctx context.Context, appToken, tableID string, records []UpdateRecordRequest) ([]*Record, error) {
 	// 转换为官方 SDK 格式
 	larkRecords := make([]*larkbitable.AppTableRecord, 0, len(records))
 	for _, record := range records {
@@ -109,44 +244,116 @@ func (c *MultiTableClient) BatchUpdateRecords(appToken, tableID string, records
 			Build()).
 		Build()
 
-	resp, err := c.client.Bitable.AppTableRecord.BatchUpdate(context.Background(), req)
+	var resp *larkbitable.BatchUpdateAppTableRecordResp
+	err := c.do(ctx, EndpointRecordWrite, true, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTableRecord.BatchUpdate(ctx, req)
+		if err != nil {
+			return fmt.Errorf("批量更新记录失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("批量更新记录", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("批量更新记录失败: %v", err)
+		return nil, err
 	}
 
-	if !resp.Success() {
-		return fmt.Errorf("批量更新记录失败 [code=%d]: %s", resp.Code, resp.Msg)
+	updated := make([]*Record, 0, len(resp.Data.Records))
+	for _, record := range resp.Data.Records {
+		updated = append(updated, newRecord(record))
 	}
 
-	return nil
+	return updated, nil
 }
 
 // DeleteRecord 删除记录
 func (c *MultiTableClient) DeleteRecord(appToken, tableID, recordID string) error {
+	return c.DeleteRecordContext(context.Background(), appToken, tableID, recordID)
+}
+
+// DeleteRecordContext 删除记录（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) DeleteRecordContext(ctx context.Context, appToken, tableID, recordID string) error {
 	req := larkbitable.NewDeleteAppTableRecordReqBuilder().
 		AppToken(appToken).
 		TableId(tableID).
 		RecordId(recordID).
 		Build()
 
-	resp, err := c.client.Bitable.AppTableRecord.Delete(context.Background(), req)
+	var resp *larkbitable.DeleteAppTableRecordResp
+	err := c.do(ctx, EndpointRecordWrite, true, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTableRecord.Delete(ctx, req)
+		if err != nil {
+			return fmt.Errorf("删除记录失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("删除记录", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("删除记录失败: %v", err)
+		return err
 	}
 
-	if !resp.Success() {
-		return fmt.Errorf("删除记录失败 [code=%d]: %s", resp.Code, resp.Msg)
+	return nil
+}
+
+// BatchDeleteRecords 批量删除记录。
+// 超过 MaxBatchSize 条时自动分批请求，部分批次失败时返回 *BatchError。
+func (c *MultiTableClient) BatchDeleteRecords(appToken, tableID string, recordIDs []string) error {
+	return c.BatchDeleteRecordsContext(context.Background(), appToken, tableID, recordIDs)
+}
+
+// BatchDeleteRecordsContext 批量删除记录（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) BatchDeleteRecordsContext(ctx context.Context, appToken, tableID string, recordIDs []string) error {
+	return c.runChunks(ctx, "批量删除记录", len(recordIDs), func(start, end int) error {
+		return c.batchDeleteRecords(ctx, appToken, tableID, recordIDs[start:end])
+	})
+}
+
+// batchDeleteRecords 以单次请求批量删除记录，recordIDs 不能超过 MaxBatchSize 条
+func (c *MultiTableClient) batchDeleteRecords(ctx context.Context, appToken, tableID string, recordIDs []string) error {
+	req := larkbitable.NewBatchDeleteAppTableRecordReqBuilder().
+		AppToken(appToken).
+		TableId(tableID).
+		Body(larkbitable.NewBatchDeleteAppTableRecordReqBodyBuilder().
+			Records(recordIDs).
+			Build()).
+		Build()
+
+	var resp *larkbitable.BatchDeleteAppTableRecordResp
+	err := c.do(ctx, EndpointRecordWrite, true, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTableRecord.BatchDelete(ctx, req)
+		if err != nil {
+			return fmt.Errorf("批量删除记录失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("批量删除记录", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	return nil
 }
 
-// ListRecords 查询记录
-func (c *MultiTableClient) ListRecords(appToken, tableID string, pageSize int, pageToken string) ([]map[string]interface{}, string, bool, error) {
+// ListRecords 查询记录，返回的记录包含创建和修改信息
+func (c *MultiTableClient) ListRecords(appToken, tableID string, pageSize int, pageToken string) ([]*Record, string, bool, error) {
+	return c.ListRecordsContext(context.Background(), appToken, tableID, pageSize, pageToken)
+}
+
+// ListRecordsContext 查询记录（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) ListRecordsContext(ctx context.Context, appToken, tableID string, pageSize int, pageToken string) ([]*Record, string, bool, error) {
 	reqBuilder := larkbitable.NewListAppTableRecordReqBuilder().
 		AppToken(appToken).
 		TableId(tableID).
-		PageSize(pageSize)
+		PageSize(pageSize).
+		AutomaticFields(true)
 
 	if pageToken != "" {
 		reqBuilder = reqBuilder.PageToken(pageToken)
@@ -154,19 +361,26 @@ func (c *MultiTableClient) ListRecords(appToken, tableID string, pageSize int, p
 
 	req := reqBuilder.Build()
 
-	resp, err := c.client.Bitable.AppTableRecord.List(context.Background(), req)
+	var resp *larkbitable.ListAppTableRecordResp
+	err := c.do(ctx, EndpointRecordRead, true, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTableRecord.List(ctx, req)
+		if err != nil {
+			return fmt.Errorf("查询记录失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("查询记录", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, "", false, fmt.Errorf("查询记录失败: %v", err)
-	}
-
-	if !resp.Success() {
-		return nil, "", false, fmt.Errorf("查询记录失败 [code=%d]: %s", resp.Code, resp.Msg)
+		return nil, "", false, err
 	}
 
 	// 转换记录格式
-	items := make([]map[string]interface{}, 0, len(resp.Data.Items))
+	items := make([]*Record, 0, len(resp.Data.Items))
 	for _, item := range resp.Data.Items {
-		items = append(items, item.Fields)
+		items = append(items, newRecord(item))
 	}
 
 	nextPageToken := ""
@@ -179,22 +393,109 @@ func (c *MultiTableClient) ListRecords(appToken, tableID string, pageSize int, p
 	return items, nextPageToken, hasMore, nil
 }
 
-// GetRecord 获取单个记录
-func (c *MultiTableClient) GetRecord(appToken, tableID, recordID string) (map[string]interface{}, error) {
+// 查询记录的分页大小
+const (
+	DefaultPageSize = 100 // AllRecords 未指定分页大小时使用
+	MaxPageSize     = 500 // 飞书接口允许的最大分页大小
+)
+
+// AllRecords 返回遍历数据表中所有记录的迭代器，自动翻页。
+// pageSize 为每次请求的记录数，<= 0 时使用 DefaultPageSize，超过 MaxPageSize 时按 MaxPageSize 请求。
+// 请求失败时迭代器产出一次错误后结束；调用方可以随时 break，不会再发起后续请求。
+//
+//	for record, err := range client.AllRecords(appToken, tableID, 0) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *MultiTableClient) AllRecords(appToken, tableID string, pageSize int) iter.Seq2[*Record, error] {
+	return c.AllRecordsContext(context.Background(), appToken, tableID, pageSize)
+}
+
+// AllRecordsContext 返回遍历所有记录的迭代器（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) AllRecordsContext(ctx context.Context, appToken, tableID string, pageSize int) iter.Seq2[*Record, error] {
+	return paginate(pageSize, func(pageSize int, pageToken string) ([]*Record, string, bool, error) {
+		return c.ListRecordsContext(ctx, appToken, tableID, pageSize, pageToken)
+	})
+}
+
+// paginate 将按页查询的函数包装为自动翻页的迭代器，pageSize 的取值规则见 AllRecords
+func paginate(pageSize int, fetch func(pageSize int, pageToken string) ([]*Record, string, bool, error)) iter.Seq2[*Record, error] {
+	switch {
+	case pageSize <= 0:
+		pageSize = DefaultPageSize
+	case pageSize > MaxPageSize:
+		pageSize = MaxPageSize
+	}
+
+	return func(yield func(*Record, error) bool) {
+		pageToken := ""
+		for {
+			items, next, hasMore, err := fetch(pageSize, pageToken)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !hasMore || next == "" {
+				return
+			}
+			pageToken = next
+		}
+	}
+}
+
+// ListAllRecords 查询数据表中的所有记录，自动翻页
+func (c *MultiTableClient) ListAllRecords(appToken, tableID string, pageSize int) ([]*Record, error) {
+	return c.ListAllRecordsContext(context.Background(), appToken, tableID, pageSize)
+}
+
+// ListAllRecordsContext 查询数据表中的所有记录（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) ListAllRecordsContext(ctx context.Context, appToken, tableID string, pageSize int) ([]*Record, error) {
+	var records []*Record
+	for record, err := range c.AllRecordsContext(ctx, appToken, tableID, pageSize) {
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// GetRecord 获取单个记录，返回的记录包含创建和修改信息
+func (c *MultiTableClient) GetRecord(appToken, tableID, recordID string) (*Record, error) {
+	return c.GetRecordContext(context.Background(), appToken, tableID, recordID)
+}
+
+// GetRecordContext 获取单个记录（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) GetRecordContext(ctx context.Context, appToken, tableID, recordID string) (*Record, error) {
 	req := larkbitable.NewGetAppTableRecordReqBuilder().
 		AppToken(appToken).
 		TableId(tableID).
 		RecordId(recordID).
+		AutomaticFields(true).
 		Build()
 
-	resp, err := c.client.Bitable.AppTableRecord.Get(context.Background(), req)
+	var resp *larkbitable.GetAppTableRecordResp
+	err := c.do(ctx, EndpointRecordRead, true, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTableRecord.Get(ctx, req)
+		if err != nil {
+			return fmt.Errorf("获取记录失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("获取记录", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("获取记录失败: %v", err)
-	}
-
-	if !resp.Success() {
-		return nil, fmt.Errorf("获取记录失败 [code=%d]: %s", resp.Code, resp.Msg)
+		return nil, err
 	}
 
-	return resp.Data.Record.Fields, nil
+	return newRecord(resp.Data.Record), nil
 }
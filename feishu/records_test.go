@@ -0,0 +1,245 @@
+package feishu_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"feishu_bitable_demo/feishu"
+	"feishu_bitable_demo/feishu/fakeserver"
+)
+
+// fastRetry 测试用的重试策略，避免等待默认的退避时间
+var fastRetry = feishu.WithRetryPolicy(feishu.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+
+// newTestTable 在模拟服务中创建一张包含 名称、数量 两个字段的数据表
+func newTestTable(t *testing.T, opts ...feishu.Option) (*fakeserver.Server, *feishu.MultiTableClient, string, string) {
+	t.Helper()
+	srv := fakeserver.New()
+	t.Cleanup(srv.Close)
+	client := srv.NewClient(opts...)
+	appToken, tableID, err := client.CreateAppAndTable("测试", "", "测试表", feishu.Headers(
+		feishu.FieldSpec{Name: "名称", Type: feishu.FieldTypeText},
+		feishu.FieldSpec{Name: "数量", Type: feishu.FieldTypeNumber},
+	))
+	if err != nil {
+		t.Fatalf("CreateAppAndTable: %v", err)
+	}
+	return srv, client, appToken, tableID
+}
+
+// newRecords 生成 n 条名称为 记录1、记录2…… 的记录
+func newRecords(n int) []feishu.CreateRecordRequest {
+	records := make([]feishu.CreateRecordRequest, 0, n)
+	for i := 1; i <= n; i++ {
+		records = append(records, feishu.CreateRecordRequest{Fields: map[string]interface{}{
+			"名称": fmt.Sprintf("记录%d", i),
+			"数量": i,
+		}})
+	}
+	return records
+}
+
+func TestContextCancellation(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t)
+	if _, err := client.BatchCreateRecords(appToken, tableID, newRecords(3)); err != nil {
+		t.Fatalf("BatchCreateRecords: %v", err)
+	}
+	srv.SetLatency(time.Second)
+
+	calls := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{"ListAllRecordsContext", func(ctx context.Context) error {
+			_, err := client.ListAllRecordsContext(ctx, appToken, tableID, 0)
+			return err
+		}},
+		{"BatchCreateRecordsContext", func(ctx context.Context) error {
+			_, err := client.BatchCreateRecordsContext(ctx, appToken, tableID, newRecords(2))
+			return err
+		}},
+	}
+	for _, c := range calls {
+		t.Run(c.name+"/canceled", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+			if err := c.call(ctx); !errors.Is(err, context.Canceled) {
+				t.Errorf("err = %v, want context.Canceled", err)
+			}
+		})
+		t.Run(c.name+"/deadline", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			if err := c.call(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("err = %v, want context.DeadlineExceeded", err)
+			}
+			if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+				t.Errorf("returned after %v, want the request to be abandoned at the deadline", elapsed)
+			}
+		})
+	}
+}
+
+func TestContextCanceledBeforeCall(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	before := srv.RequestCount()
+	if _, err := client.ListAllRecordsContext(ctx, appToken, tableID, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("ListAllRecordsContext err = %v, want context.Canceled", err)
+	}
+	if _, err := client.BatchCreateRecordsContext(ctx, appToken, tableID, newRecords(2)); !errors.Is(err, context.Canceled) {
+		t.Errorf("BatchCreateRecordsContext err = %v, want context.Canceled", err)
+	}
+	if n := srv.RequestCount() - before; n != 0 {
+		t.Errorf("sent %d requests with a canceled ctx, want 0", n)
+	}
+}
+
+func TestUpdateRecordIfUnmodified(t *testing.T) {
+	tests := []struct {
+		name         string
+		cond         func(read *feishu.Record) feishu.UpdateCondition
+		modify       bool // 条件更新前由其他人修改记录
+		wantConflict bool
+	}{
+		{name: "未被修改", cond: feishu.IfUnmodified},
+		{name: "读取后被修改", cond: feishu.IfUnmodified, modify: true, wantConflict: true},
+		{name: "没有条件", cond: func(*feishu.Record) feishu.UpdateCondition { return feishu.UpdateCondition{} }, modify: true},
+		{
+			name: "版本一致",
+			cond: func(*feishu.Record) feishu.UpdateCondition {
+				return feishu.UpdateCondition{VersionField: "数量", Version: 1}
+			},
+		},
+		{
+			name: "版本不一致",
+			cond: func(*feishu.Record) feishu.UpdateCondition {
+				return feishu.UpdateCondition{VersionField: "数量", Version: 1}
+			},
+			modify:       true,
+			wantConflict: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, appToken, tableID := newTestTable(t)
+			created, err := client.CreateRecord(appToken, tableID, map[string]interface{}{"名称": "苹果", "数量": 1})
+			if err != nil {
+				t.Fatalf("CreateRecord: %v", err)
+			}
+			read, err := client.GetRecord(appToken, tableID, created.ID)
+			if err != nil {
+				t.Fatalf("GetRecord: %v", err)
+			}
+			if tt.modify {
+				time.Sleep(2 * time.Millisecond) // 模拟服务的修改时间精度为毫秒
+				if err := client.UpdateRecord(appToken, tableID, read.ID, map[string]interface{}{"数量": 5}); err != nil {
+					t.Fatalf("UpdateRecord: %v", err)
+				}
+			}
+
+			err = client.UpdateRecordIfUnmodified(appToken, tableID, read.ID, tt.cond(read), map[string]interface{}{"数量": 2})
+			quantity := srv.Records(appToken, tableID)[0]["数量"]
+			if !tt.wantConflict {
+				if err != nil {
+					t.Fatalf("UpdateRecordIfUnmodified: %v", err)
+				}
+				if quantity != 2.0 {
+					t.Errorf("数量 = %v, want 2", quantity)
+				}
+				return
+			}
+
+			if !errors.Is(err, feishu.ErrConflict) {
+				t.Fatalf("err = %v, want ErrConflict", err)
+			}
+			var conflict *feishu.ConflictError
+			if !errors.As(err, &conflict) {
+				t.Fatalf("err = %T, want *ConflictError", err)
+			}
+			if conflict.RecordID != read.ID || conflict.Reason == "" {
+				t.Errorf("ConflictError = %+v", conflict)
+			}
+			if conflict.Current == nil || conflict.Current.Fields["数量"] != 5.0 {
+				t.Errorf("ConflictError.Current = %+v, want the record as modified by the other writer", conflict.Current)
+			}
+			if quantity != 5.0 {
+				t.Errorf("数量 = %v, want 5 (the conflicting update must not be written)", quantity)
+			}
+		})
+	}
+}
+
+func TestUpdateRecordIfUnmodifiedNotFound(t *testing.T) {
+	_, client, appToken, tableID := newTestTable(t)
+	err := client.UpdateRecordIfUnmodified(appToken, tableID, "recNotExist", feishu.UpdateCondition{}, map[string]interface{}{"数量": 2})
+	if !errors.Is(err, feishu.ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestAllRecordsBreak(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t)
+	if _, err := client.BatchCreateRecords(appToken, tableID, newRecords(10)); err != nil {
+		t.Fatalf("BatchCreateRecords: %v", err)
+	}
+
+	for _, tt := range []struct {
+		stopAfter int // 读取到第几条记录时 break
+		wantPages int
+	}{
+		{stopAfter: 1, wantPages: 1},
+		{stopAfter: 3, wantPages: 1}, // 恰好读完第一页时不预取下一页
+		{stopAfter: 4, wantPages: 2},
+	} {
+		before := srv.RequestCount()
+		var n int
+		for _, err := range client.AllRecords(appToken, tableID, 3) {
+			if err != nil {
+				t.Fatalf("AllRecords: %v", err)
+			}
+			if n++; n == tt.stopAfter {
+				break
+			}
+		}
+		if pages := srv.RequestCount() - before; pages != tt.wantPages {
+			t.Errorf("break after %d records: fetched %d pages, want %d", tt.stopAfter, pages, tt.wantPages)
+		}
+	}
+}
+
+func TestAllRecordsError(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t, fastRetry)
+	if _, err := client.BatchCreateRecords(appToken, tableID, newRecords(10)); err != nil {
+		t.Fatalf("BatchCreateRecords: %v", err)
+	}
+
+	var records, errs int
+	for record, err := range client.AllRecords(appToken, tableID, 3) {
+		if errs > 0 {
+			t.Fatalf("iteration continued after the error: %v, %v", record, err)
+		}
+		if err != nil {
+			var apiErr *feishu.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != 1255002 || record != nil {
+				t.Errorf("AllRecords = %v, %v; want the bad gateway error", record, err)
+			}
+			errs++
+			continue
+		}
+		if records++; records == 1 {
+			// 第二页的请求及其所有重试都失败
+			srv.FailNextAfterProcessing(3, http.StatusBadGateway, 1255002, "bad gateway")
+		}
+	}
+	if records != 3 || errs != 1 {
+		t.Errorf("got %d records and %d errors, want the first page and then one error", records, errs)
+	}
+}
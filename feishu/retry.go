@@ -0,0 +1,141 @@
+package feishu
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy 重试策略
+type RetryPolicy struct {
+	MaxAttempts int           // 最大尝试次数（包含首次请求），小于等于 1 表示不重试
+	BaseDelay   time.Duration // 首次重试前的等待时间，之后按 2 的指数增长
+	MaxDelay    time.Duration // 单次等待时间上限
+}
+
+// DefaultRetryPolicy 默认重试策略：最多 3 次，500ms 起指数退避
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// WithRetryPolicy 设置重试策略
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retry = policy
+	}
+}
+
+// WithoutRetry 关闭自动重试
+func WithoutRetry() Option {
+	return WithRetryPolicy(RetryPolicy{MaxAttempts: 1})
+}
+
+// 飞书网关返回的限流相关响应头
+const (
+	headerRateLimitReset = "X-Ogw-Ratelimit-Reset" // 距离限流窗口重置的秒数
+	headerRetryAfter     = "Retry-After"
+)
+
+// retryAfterFromHeader 从响应头中解析服务端建议的等待时间
+func retryAfterFromHeader(header http.Header) time.Duration {
+	for _, key := range []string{headerRateLimitReset, headerRetryAfter} {
+		if v := header.Get(key); v != "" {
+			if seconds, err := strconv.Atoi(v); err == nil && seconds > 0 {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return 0
+}
+
+// do 执行一次接口调用：每次发出请求前按接口类别限流，失败时按重试策略重试。
+// idempotent 为 false 的调用（如不支持 client_token 的创建类接口）只在被限流时重试，此时请求未被服务端处理，重发是安全的；
+// idempotent 为 true 的调用还会在服务端错误和网络错误时重试。
+func (c *MultiTableClient) do(ctx context.Context, endpoint Endpoint, idempotent bool, call func() error) error {
+	policy := c.retry
+	for attempt := 1; ; attempt++ {
+		if err := c.limiter.wait(ctx, endpoint); err != nil {
+			return err
+		}
+
+		err := call()
+		if err != nil && ctx.Err() != nil {
+			return &contextError{err: err, ctxErr: ctx.Err()}
+		}
+		if err == nil || attempt >= policy.MaxAttempts || !shouldRetry(ctx, err, idempotent) {
+			return err
+		}
+
+		delay := policy.backoff(attempt)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > delay {
+			delay = apiErr.RetryAfter
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &contextError{err: err, ctxErr: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+}
+
+// contextError 请求因 ctx 取消或超时而失败。
+// SDK 会把超时转换为自己的错误类型，这里保留原始错误信息的同时支持 errors.Is(err, context.DeadlineExceeded)。
+type contextError struct {
+	err    error
+	ctxErr error
+}
+
+func (e *contextError) Error() string {
+	return e.err.Error()
+}
+
+func (e *contextError) Unwrap() []error {
+	return []error{e.err, e.ctxErr}
+}
+
+// shouldRetry 判断错误是否值得重试
+func shouldRetry(ctx context.Context, err error, idempotent bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if errors.Is(apiErr, ErrRateLimited) {
+			return true
+		}
+		return idempotent && apiErr.Retryable()
+	}
+	// 网络错误：无法确定请求是否已被处理，只对幂等调用重试
+	return idempotent
+}
+
+// backoff 计算第 attempt 次失败后的等待时间（带随机抖动的指数退避）
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	// 逐次翻倍，达到上限后停止，避免尝试次数较大时移位溢出
+	for i := 1; i < attempt && delay > 0 && delay <= math.MaxInt64/2; i++ {
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+		delay *= 2
+	}
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// 在 [delay/2, delay] 之间随机，避免多个客户端同时重试
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
@@ -0,0 +1,49 @@
+package feishu
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		attempt  int
+		min, max time.Duration
+	}{
+		{"首次重试", DefaultRetryPolicy, 1, 250 * time.Millisecond, 500 * time.Millisecond},
+		{"指数增长", DefaultRetryPolicy, 3, time.Second, 2 * time.Second},
+		{"达到上限", DefaultRetryPolicy, 6, 5 * time.Second, 10 * time.Second},
+		{"尝试次数超过位宽", DefaultRetryPolicy, 100, 5 * time.Second, 10 * time.Second},
+		{"没有上限", RetryPolicy{BaseDelay: time.Second}, 100, math.MaxInt64 / 4, math.MaxInt64},
+		{"没有基础等待时间", RetryPolicy{MaxDelay: time.Second}, 2, 500 * time.Millisecond, time.Second},
+		{"不等待", RetryPolicy{}, 5, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if got := tt.policy.backoff(tt.attempt); got < tt.min || got > tt.max {
+					t.Fatalf("backoff(%d) = %v, want in [%v, %v]", tt.attempt, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoffNeverOverflows(t *testing.T) {
+	policies := []RetryPolicy{
+		DefaultRetryPolicy,
+		{BaseDelay: 3 * time.Millisecond, MaxDelay: 10 * time.Second},
+		{BaseDelay: 7, MaxDelay: time.Hour},
+	}
+	for _, policy := range policies {
+		// 达到上限之后，无论尝试多少次都应保持在 [MaxDelay/2, MaxDelay] 之间
+		for attempt := 64; attempt <= 200; attempt++ {
+			if got := policy.backoff(attempt); got < policy.MaxDelay/2 || got > policy.MaxDelay {
+				t.Fatalf("%+v: backoff(%d) = %v, want in [%v, %v]", policy, attempt, got, policy.MaxDelay/2, policy.MaxDelay)
+			}
+		}
+	}
+}
@@ -9,6 +9,11 @@ import (
 
 // CreateApp 创建多维表格
 func (c *MultiTableClient) CreateApp(name, folderToken string) (string, error) {
+	return c.CreateAppContext(context.Background(), name, folderToken)
+}
+
+// CreateAppContext 创建多维表格（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) CreateAppContext(ctx context.Context, name, folderToken string) (string, error) {
 	req := larkbitable.NewCreateAppReqBuilder().
 		ReqApp(larkbitable.NewReqAppBuilder().
 			Name(name).
@@ -16,13 +21,20 @@ func (c *MultiTableClient) CreateApp(name, folderToken string) (string, error) {
 			Build()).
 		Build()
 
-	resp, err := c.client.Bitable.App.Create(context.Background(), req)
+	var resp *larkbitable.CreateAppResp
+	err := c.do(ctx, EndpointDefault, false, func() error {
+		var err error
+		resp, err = c.client.Bitable.App.Create(ctx, req)
+		if err != nil {
+			return fmt.Errorf("创建多维表格失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("创建多维表格", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("创建多维表格失败: %v", err)
-	}
-
-	if !resp.Success() {
-		return "", fmt.Errorf("创建多维表格失败 [code=%d]: %s", resp.Code, resp.Msg)
+		return "", err
 	}
 
 	return *resp.Data.App.AppToken, nil
@@ -30,6 +42,11 @@ func (c *MultiTableClient) CreateApp(name, folderToken string) (string, error) {
 
 // CreateTable 创建数据表
 func (c *MultiTableClient) CreateTable(appToken, tableName string, fields []*larkbitable.AppTableCreateHeader) (string, error) {
+	return c.CreateTableContext(context.Background(), appToken, tableName, fields)
+}
+
+// CreateTableContext 创建数据表（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) CreateTableContext(ctx context.Context, appToken, tableName string, fields []*larkbitable.AppTableCreateHeader) (string, error) {
 	table := larkbitable.NewReqTableBuilder().
 		Name(tableName).
 		Fields(fields).
@@ -42,13 +59,20 @@ func (c *MultiTableClient) CreateTable(appToken, tableName string, fields []*lar
 			Build()).
 		Build()
 
-	resp, err := c.client.Bitable.AppTable.Create(context.Background(), req)
+	var resp *larkbitable.CreateAppTableResp
+	err := c.do(ctx, EndpointDefault, false, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTable.Create(ctx, req)
+		if err != nil {
+			return fmt.Errorf("创建数据表失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("创建数据表", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("创建数据表失败: %v", err)
-	}
-
-	if !resp.Success() {
-		return "", fmt.Errorf("创建数据表失败 [code=%d]: %s", resp.Code, resp.Msg)
+		return "", err
 	}
 
 	return *resp.Data.TableId, nil
@@ -56,14 +80,19 @@ func (c *MultiTableClient) CreateTable(appToken, tableName string, fields []*lar
 
 // CreateAppAndTable 创建多维表格并添加数据表（组合操作）
 func (c *MultiTableClient) CreateAppAndTable(appName, folderToken, tableName string, fields []*larkbitable.AppTableCreateHeader) (appToken, tableID string, err error) {
+	return c.CreateAppAndTableContext(context.Background(), appName, folderToken, tableName, fields)
+}
+
+// CreateAppAndTableContext 创建多维表格并添加数据表（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) CreateAppAndTableContext(ctx context.Context, appName, folderToken, tableName string, fields []*larkbitable.AppTableCreateHeader) (appToken, tableID string, err error) {
 	// 创建多维表格
-	appToken, err = c.CreateApp(appName, folderToken)
+	appToken, err = c.CreateAppContext(ctx, appName, folderToken)
 	if err != nil {
 		return "", "", err
 	}
 
 	// 创建数据表
-	tableID, err = c.CreateTable(appToken, tableName, fields)
+	tableID, err = c.CreateTableContext(ctx, appToken, tableName, fields)
 	if err != nil {
 		return appToken, "", err
 	}
@@ -71,20 +100,77 @@ func (c *MultiTableClient) CreateAppAndTable(appName, folderToken, tableName str
 	return appToken, tableID, nil
 }
 
-// ListTables 列出多维表格中的所有数据表
+// ListTables 列出多维表格中的所有数据表，数据表较多时自动翻页
 func (c *MultiTableClient) ListTables(appToken string) ([]*larkbitable.AppTable, error) {
-	req := larkbitable.NewListAppTableReqBuilder().
+	return c.ListTablesContext(context.Background(), appToken)
+}
+
+// ListTablesContext 列出多维表格中的所有数据表（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) ListTablesContext(ctx context.Context, appToken string) ([]*larkbitable.AppTable, error) {
+	var (
+		tables    []*larkbitable.AppTable
+		pageToken string
+	)
+	for {
+		builder := larkbitable.NewListAppTableReqBuilder().
+			AppToken(appToken).
+			PageSize(DefaultPageSize)
+		if pageToken != "" {
+			builder.PageToken(pageToken)
+		}
+		req := builder.Build()
+
+		var resp *larkbitable.ListAppTableResp
+		err := c.do(ctx, EndpointDefault, true, func() error {
+			var err error
+			resp, err = c.client.Bitable.AppTable.List(ctx, req)
+			if err != nil {
+				return fmt.Errorf("列出数据表失败: %w", err)
+			}
+			if !resp.Success() {
+				return newAPIError("列出数据表", resp.ApiResp, resp.CodeError)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		tables = append(tables, resp.Data.Items...)
+		pageToken = StringValue(resp.Data.PageToken)
+		if resp.Data.HasMore == nil || !*resp.Data.HasMore || pageToken == "" {
+			return tables, nil
+		}
+	}
+}
+
+// DeleteTable 删除数据表及其中的所有记录，多维表格中的最后一张数据表不能删除
+func (c *MultiTableClient) DeleteTable(appToken, tableID string) error {
+	return c.DeleteTableContext(context.Background(), appToken, tableID)
+}
+
+// DeleteTableContext 删除数据表（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) DeleteTableContext(ctx context.Context, appToken, tableID string) error {
+	req := larkbitable.NewDeleteAppTableReqBuilder().
 		AppToken(appToken).
+		TableId(tableID).
 		Build()
 
-	resp, err := c.client.Bitable.AppTable.List(context.Background(), req)
+	var resp *larkbitable.DeleteAppTableResp
+	err := c.do(ctx, EndpointDefault, true, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTable.Delete(ctx, req)
+		if err != nil {
+			return fmt.Errorf("删除数据表失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("删除数据表", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("列出数据表失败: %v", err)
-	}
-
-	if !resp.Success() {
-		return nil, fmt.Errorf("列出数据表失败 [code=%d]: %s", resp.Code, resp.Msg)
+		return err
 	}
 
-	return resp.Data.Items, nil
+	return nil
 }
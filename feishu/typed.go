@@ -0,0 +1,158 @@
+package feishu
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// Table 绑定到一张数据表的类型化记录操作，记录与 T 之间按 bitable 标签转换（见 MarshalFields）。
+// T 必须是结构体类型；Update 要求 T 包含带 record_id 选项的字段。
+//
+//	products := feishu.NewTable[Product](client, appToken, tableID)
+//	created, err := products.Create(Product{Name: "iPad", Stock: 10})
+//	for p, err := range products.Query(feishu.NewQuery().Where(feishu.IsLess("库存数量", 20)), 0) {
+//		...
+//	}
+type Table[T any] struct {
+	client   *MultiTableClient
+	appToken string
+	tableID  string
+}
+
+// NewTable 创建绑定到 appToken、tableID 的 Table
+func NewTable[T any](client *MultiTableClient, appToken, tableID string) *Table[T] {
+	return &Table[T]{client: client, appToken: appToken, tableID: tableID}
+}
+
+// Create 创建记录，返回写入后的值（含 record_id）
+func (t *Table[T]) Create(v T) (*T, error) {
+	return t.CreateContext(context.Background(), v)
+}
+
+// CreateContext 创建记录（支持通过 ctx 取消和设置超时）
+func (t *Table[T]) CreateContext(ctx context.Context, v T) (*T, error) {
+	fields, err := MarshalFields(v)
+	if err != nil {
+		return nil, err
+	}
+	record, err := t.client.CreateRecordContext(ctx, t.appToken, t.tableID, fields)
+	if err != nil {
+		return nil, err
+	}
+	return t.decode(record)
+}
+
+// Get 读取记录
+func (t *Table[T]) Get(recordID string) (*T, error) {
+	return t.GetContext(context.Background(), recordID)
+}
+
+// GetContext 读取记录（支持通过 ctx 取消和设置超时）
+func (t *Table[T]) GetContext(ctx context.Context, recordID string) (*T, error) {
+	record, err := t.client.GetRecordContext(ctx, t.appToken, t.tableID, recordID)
+	if err != nil {
+		return nil, err
+	}
+	return t.decode(record)
+}
+
+// Update 以 v 的内容更新 record_id 字段对应的记录。
+// v 中所有带标签的字段都会写入，零值会清空对应字段，不需要写入的字段应使用 omitempty 选项。
+func (t *Table[T]) Update(v T) error {
+	return t.UpdateContext(context.Background(), v)
+}
+
+// UpdateContext 更新记录（支持通过 ctx 取消和设置超时）
+func (t *Table[T]) UpdateContext(ctx context.Context, v T) error {
+	recordID, err := recordIDOf(v)
+	if err != nil {
+		return err
+	}
+	if recordID == "" {
+		return errors.New("record_id 为空，无法更新记录")
+	}
+	fields, err := MarshalFields(v)
+	if err != nil {
+		return err
+	}
+	return t.client.UpdateRecordContext(ctx, t.appToken, t.tableID, recordID, fields)
+}
+
+// Delete 删除记录
+func (t *Table[T]) Delete(recordID string) error {
+	return t.DeleteContext(context.Background(), recordID)
+}
+
+// DeleteContext 删除记录（支持通过 ctx 取消和设置超时）
+func (t *Table[T]) DeleteContext(ctx context.Context, recordID string) error {
+	return t.client.DeleteRecordContext(ctx, t.appToken, t.tableID, recordID)
+}
+
+// List 返回遍历数据表中所有记录的迭代器，自动翻页，pageSize 的取值规则见 AllRecords。
+// 记录无法转换为 T 时迭代器产出一次 *FieldTypeError 后结束。
+func (t *Table[T]) List(pageSize int) iter.Seq2[*T, error] {
+	return t.ListContext(context.Background(), pageSize)
+}
+
+// ListContext 返回遍历所有记录的迭代器（支持通过 ctx 取消和设置超时）
+func (t *Table[T]) ListContext(ctx context.Context, pageSize int) iter.Seq2[*T, error] {
+	return t.decodeAll(t.client.AllRecordsContext(ctx, t.appToken, t.tableID, pageSize))
+}
+
+// Query 返回遍历满足查询条件的记录的迭代器，自动翻页，q 为 nil 时遍历所有记录
+func (t *Table[T]) Query(q *Query, pageSize int) iter.Seq2[*T, error] {
+	return t.QueryContext(context.Background(), q, pageSize)
+}
+
+// QueryContext 返回遍历满足查询条件的记录的迭代器（支持通过 ctx 取消和设置超时）
+func (t *Table[T]) QueryContext(ctx context.Context, q *Query, pageSize int) iter.Seq2[*T, error] {
+	return t.decodeAll(t.client.QueryRecordsContext(ctx, t.appToken, t.tableID, q, pageSize))
+}
+
+// Upsert 按键字段新建或更新记录，keyFields 为多维表格中的字段名，行为见 MultiTableClient.Upsert
+func (t *Table[T]) Upsert(keyFields []string, values []T) (*UpsertResult, error) {
+	return t.UpsertContext(context.Background(), keyFields, values)
+}
+
+// UpsertContext 按键字段新建或更新记录（支持通过 ctx 取消和设置超时）
+func (t *Table[T]) UpsertContext(ctx context.Context, keyFields []string, values []T) (*UpsertResult, error) {
+	records := make([]CreateRecordRequest, len(values))
+	for i, v := range values {
+		fields, err := MarshalFields(v)
+		if err != nil {
+			return nil, err
+		}
+		records[i] = CreateRecordRequest{Fields: fields}
+	}
+	return t.client.UpsertContext(ctx, t.appToken, t.tableID, keyFields, records)
+}
+
+// decode 将记录转换为 T
+func (t *Table[T]) decode(record *Record) (*T, error) {
+	v := new(T)
+	if err := UnmarshalRecord(record, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// decodeAll 将记录迭代器转换为 T 的迭代器
+func (t *Table[T]) decodeAll(records iter.Seq2[*Record, error]) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		for record, err := range records {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			v, err := t.decode(record)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package feishu_test
+
+import (
+	"errors"
+	"testing"
+
+	"feishu_bitable_demo/feishu"
+)
+
+type item struct {
+	ID       string `bitable:",record_id"`
+	Name     string `bitable:"名称"`
+	Quantity int    `bitable:"数量"`
+	OnSale   bool   `bitable:"上架"`
+	Note     string `bitable:"备注,omitempty"`
+	Label    string `bitable:"标签,readonly"`
+}
+
+// newItemTable 在 newTestTable 的基础上增加 item 用到的字段
+func newItemTable(t *testing.T) (*feishu.MultiTableClient, *feishu.Table[item], func() []map[string]interface{}, string, string) {
+	t.Helper()
+	srv, client, appToken, tableID := newTestTable(t)
+	for _, spec := range []feishu.FieldSpec{
+		{Name: "上架", Type: feishu.FieldTypeCheckbox},
+		{Name: "备注", Type: feishu.FieldTypeText},
+		{Name: "标签", Type: feishu.FieldTypeText},
+	} {
+		if _, err := client.CreateField(appToken, tableID, spec); err != nil {
+			t.Fatalf("CreateField: %v", err)
+		}
+	}
+	records := func() []map[string]interface{} { return srv.Records(appToken, tableID) }
+	return client, feishu.NewTable[item](client, appToken, tableID), records, appToken, tableID
+}
+
+func TestTableRoundTrip(t *testing.T) {
+	client, items, records, appToken, tableID := newItemTable(t)
+
+	created, err := items.Create(item{Name: "苹果", Quantity: 3, OnSale: true, Label: "不写入"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID == "" || created.Name != "苹果" || created.Quantity != 3 || !created.OnSale {
+		t.Fatalf("Create = %+v", created)
+	}
+	stored := records()[0]
+	if _, ok := stored["标签"]; ok {
+		t.Errorf("readonly field written: %v", stored)
+	}
+	if _, ok := stored["备注"]; ok {
+		t.Errorf("empty omitempty field written: %v", stored)
+	}
+
+	// 只读字段和 omitempty 字段由其他途径写入后可以读取
+	if err := client.UpdateRecord(appToken, tableID, created.ID, map[string]interface{}{"标签": "进口", "备注": "旧备注"}); err != nil {
+		t.Fatalf("UpdateRecord: %v", err)
+	}
+	got, err := items.Get(created.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	want := item{ID: created.ID, Name: "苹果", Quantity: 3, OnSale: true, Note: "旧备注", Label: "进口"}
+	if *got != want {
+		t.Errorf("Get = %+v, want %+v", *got, want)
+	}
+
+	// Update 写入所有非 omitempty、非只读的字段
+	if err := items.Update(item{ID: created.ID, Name: "苹果", Quantity: 5, Label: "本地"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, err = items.Get(created.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	want = item{ID: created.ID, Name: "苹果", Quantity: 5, OnSale: false, Note: "旧备注", Label: "进口"}
+	if *got != want {
+		t.Errorf("Get after Update = %+v, want %+v", *got, want)
+	}
+	if err := items.Update(item{Name: "苹果"}); err == nil {
+		t.Error("Update without record_id: want error")
+	}
+
+	// Upsert：苹果与已有记录一致，香蕉新建
+	result, err := items.Upsert([]string{"名称"}, []item{{Name: "苹果", Quantity: 5}, {Name: "香蕉", Quantity: 1, OnSale: true}})
+	if err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if result.Unchanged != 1 || result.Created != 1 || result.RecordIDs[0] != created.ID {
+		t.Errorf("Upsert = %+v, want 苹果 unchanged and 香蕉 created", result)
+	}
+
+	var names []string
+	for v, err := range items.List(1) {
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if v.ID == "" {
+			t.Errorf("List item %+v has no record_id", v)
+		}
+		names = append(names, v.Name)
+	}
+	if len(names) != 2 || names[0] != "苹果" || names[1] != "香蕉" {
+		t.Errorf("List = %q, want 苹果, 香蕉", names)
+	}
+
+	names = nil
+	for v, err := range items.Query(feishu.NewQuery().Where(feishu.Is("上架", true)), 0) {
+		if err != nil {
+			t.Fatalf("Query: %v", err)
+		}
+		names = append(names, v.Name)
+	}
+	if len(names) != 1 || names[0] != "香蕉" {
+		t.Errorf("Query = %q, want 香蕉", names)
+	}
+
+	if err := items.Delete(created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := items.Get(created.ID); !errors.Is(err, feishu.ErrNotFound) {
+		t.Errorf("Get after Delete: err = %v, want ErrNotFound", err)
+	}
+	if n := len(records()); n != 1 {
+		t.Errorf("%d records left, want 1", n)
+	}
+}
+
+func TestTableDecodeError(t *testing.T) {
+	type wrongItem struct {
+		Quantity bool `bitable:"数量"`
+	}
+	_, client, appToken, tableID := newTestTable(t)
+	if _, err := client.BatchCreateRecords(appToken, tableID, newRecords(3)); err != nil {
+		t.Fatalf("BatchCreateRecords: %v", err)
+	}
+
+	var calls int
+	for v, err := range feishu.NewTable[wrongItem](client, appToken, tableID).List(0) {
+		calls++
+		var typeErr *feishu.FieldTypeError
+		if !errors.As(err, &typeErr) || typeErr.Field != "数量" || v != nil {
+			t.Errorf("List = %v, %v; want a *FieldTypeError for 数量", v, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("List yielded %d times, want the error once", calls)
+	}
+}
@@ -1,6 +1,68 @@
 package feishu
 
+import (
+	"time"
+
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+)
+
 // CreateRecordRequest 创建记录请求
 type CreateRecordRequest struct {
 	Fields map[string]interface{}
 }
+
+// UpdateRecordRequest 更新记录请求，Fields 中未包含的字段保持不变
+type UpdateRecordRequest struct {
+	RecordID string
+	Fields   map[string]interface{}
+}
+
+// Record 多维表格中的一条记录。
+// 创建时间、修改时间和创建人、修改人仅在查询记录（ListRecords、GetRecord 等）时返回。
+type Record struct {
+	ID     string                 `json:"record_id"`
+	Fields map[string]interface{} `json:"fields"`
+
+	CreatedTime      time.Time `json:"created_time"`
+	LastModifiedTime time.Time `json:"last_modified_time"`
+	CreatedBy        *User     `json:"created_by,omitempty"`
+	LastModifiedBy   *User     `json:"last_modified_by,omitempty"`
+}
+
+// User 飞书用户（记录的创建人、修改人等）
+type User struct {
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	EnName string `json:"en_name,omitempty"`
+	Email  string `json:"email,omitempty"`
+}
+
+// newRecord 将 SDK 返回的记录转换为 Record
+func newRecord(r *larkbitable.AppTableRecord) *Record {
+	rec := &Record{
+		ID:             StringValue(r.RecordId),
+		Fields:         r.Fields,
+		CreatedBy:      newUser(r.CreatedBy),
+		LastModifiedBy: newUser(r.LastModifiedBy),
+	}
+	if r.CreatedTime != nil {
+		rec.CreatedTime = time.UnixMilli(*r.CreatedTime)
+	}
+	if r.LastModifiedTime != nil {
+		rec.LastModifiedTime = time.UnixMilli(*r.LastModifiedTime)
+	}
+	return rec
+}
+
+// newUser 将 SDK 返回的人员信息转换为 User，p 为 nil 时返回 nil
+func newUser(p *larkbitable.Person) *User {
+	if p == nil {
+		return nil
+	}
+	return &User{
+		ID:     StringValue(p.Id),
+		Name:   StringValue(p.Name),
+		EnName: StringValue(p.EnName),
+		Email:  StringValue(p.Email),
+	}
+}
@@ -0,0 +1,242 @@
+package feishu
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UpsertAction Upsert 对单条输入记录执行的操作
+type UpsertAction string
+
+const (
+	UpsertCreated   UpsertAction = "created"   // 新建了记录
+	UpsertUpdated   UpsertAction = "updated"   // 更新了已有记录
+	UpsertUnchanged UpsertAction = "unchanged" // 已有记录的字段与输入一致，未发起请求
+	UpsertFailed    UpsertAction = "failed"    // 新建或更新失败，原因见返回的 *BatchError
+)
+
+// UpsertResult Upsert 的执行结果
+type UpsertResult struct {
+	Created   int
+	Updated   int
+	Unchanged int
+
+	// RecordIDs 与输入顺序一致的 record_id；新建失败的记录为空字符串，更新失败的记录仍为已有记录的 record_id
+	RecordIDs []string
+	// Actions 与输入顺序一致的操作类型
+	Actions []UpsertAction
+}
+
+// Upsert 按键字段写入记录：数据表中不存在相同键的记录时新建，存在时只更新值有变化的字段，
+// 所有字段都一致时不发起请求。keyFields 为一个或多个作为唯一键的字段名（如 产品名称），
+// 每条输入记录都必须包含这些字段。
+//
+// Upsert 会先读取整张数据表以查找已有记录，再调用 BatchCreateRecords 和 BatchUpdateRecords；
+// 部分批次失败时返回已完成部分的结果和 *BatchError。
+// 输入中或数据表中存在键相同的多条记录时返回错误，不写入任何数据。
+func (c *MultiTableClient) Upsert(appToken, tableID string, keyFields []string, records []CreateRecordRequest) (*UpsertResult, error) {
+	return c.UpsertContext(context.Background(), appToken, tableID, keyFields, records)
+}
+
+// UpsertContext 按键字段写入记录（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) UpsertContext(ctx context.Context, appToken, tableID string, keyFields []string, records []CreateRecordRequest) (*UpsertResult, error) {
+	if len(keyFields) == 0 {
+		return nil, errors.New("需要至少一个键字段")
+	}
+
+	// 校验输入的键
+	keys := make([]string, len(records))
+	inputs := make(map[string]int, len(records))
+	for i, record := range records {
+		key, err := upsertKey(keyFields, record.Fields)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 条记录%w", i+1, err)
+		}
+		if j, ok := inputs[key]; ok {
+			return nil, fmt.Errorf("第 %d 条和第 %d 条记录的键相同: %s", j+1, i+1, describeKey(keyFields, key))
+		}
+		inputs[key] = i
+		keys[i] = key
+	}
+
+	// 读取已有记录
+	existing := make(map[string]*Record)
+	for record, err := range c.AllRecordsContext(ctx, appToken, tableID, MaxPageSize) {
+		if err != nil {
+			return nil, err
+		}
+		key, err := upsertKey(keyFields, record.Fields)
+		if err != nil {
+			continue // 键字段为空的记录不参与匹配
+		}
+		if _, ok := inputs[key]; !ok {
+			continue
+		}
+		if other, ok := existing[key]; ok {
+			return nil, fmt.Errorf("数据表中记录 %s 和 %s 的键相同: %s", other.ID, record.ID, describeKey(keyFields, key))
+		}
+		existing[key] = record
+	}
+
+	result := &UpsertResult{
+		RecordIDs: make([]string, len(records)),
+		Actions:   make([]UpsertAction, len(records)),
+	}
+	var (
+		creates       []CreateRecordRequest
+		createIndexes []int
+		updates       []UpdateRecordRequest
+		updateIndexes []int
+	)
+	for i, record := range records {
+		current, ok := existing[keys[i]]
+		if !ok {
+			creates = append(creates, record)
+			createIndexes = append(createIndexes, i)
+			continue
+		}
+
+		result.RecordIDs[i] = current.ID
+		changed := changedFields(current.Fields, record.Fields)
+		if len(changed) == 0 {
+			result.Actions[i] = UpsertUnchanged
+			result.Unchanged++
+			continue
+		}
+		updates = append(updates, UpdateRecordRequest{RecordID: current.ID, Fields: changed})
+		updateIndexes = append(updateIndexes, i)
+	}
+
+	var errs []error
+	if len(creates) > 0 {
+		created, err := c.BatchCreateRecordsContext(ctx, appToken, tableID, creates)
+		for j, record := range created.Records {
+			i := createIndexes[j]
+			if record == nil {
+				result.Actions[i] = UpsertFailed
+				continue
+			}
+			result.Actions[i] = UpsertCreated
+			result.RecordIDs[i] = record.ID
+			result.Created++
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(updates) > 0 {
+		updated, err := c.BatchUpdateRecordsContext(ctx, appToken, tableID, updates)
+		for j, record := range updated.Records {
+			i := updateIndexes[j]
+			if record == nil {
+				result.Actions[i] = UpsertFailed
+				continue
+			}
+			result.Actions[i] = UpsertUpdated
+			result.Updated++
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// upsertKey 由键字段的值组成记录的键，任一键字段为空时返回错误
+func upsertKey(keyFields []string, fields map[string]interface{}) (string, error) {
+	parts := make([]string, 0, len(keyFields))
+	for _, name := range keyFields {
+		value := comparableValue(fields[name])
+		if value == "" {
+			return "", fmt.Errorf("缺少键字段 %s", name)
+		}
+		parts = append(parts, value)
+	}
+	return strings.Join(parts, "\x00"), nil
+}
+
+// describeKey 将键格式化为 "字段=值" 的形式，用于错误信息
+func describeKey(keyFields []string, key string) string {
+	values := strings.Split(key, "\x00")
+	parts := make([]string, 0, len(keyFields))
+	for i, name := range keyFields {
+		parts = append(parts, name+"="+values[i])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// changedFields 返回 fields 中与 current 取值不同的字段
+func changedFields(current, fields map[string]interface{}) map[string]interface{} {
+	changed := make(map[string]interface{})
+	for name, value := range fields {
+		if comparableValue(current[name]) != comparableValue(value) {
+			changed[name] = value
+		}
+	}
+	return changed
+}
+
+// comparableValue 将写入格式或读取格式的字段值转换为可比较的文本。
+// 写入和读取时同一字段的格式可能不同（如文本字段写入字符串、读取时为富文本片段），
+// 先经过 JSON 转换统一数字和容器类型，再按字段值的结构取出有意义的部分。
+func comparableValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return string(data)
+	}
+	return genericText(generic)
+}
+
+// genericText 将 JSON 解码后的值转换为文本
+func genericText(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		// 未勾选的复选框在读取时不返回，与空值视为相同
+		if !v {
+			return ""
+		}
+		return "true"
+	case map[string]interface{}:
+		for _, key := range []string{"link", "id", "text"} {
+			if s, ok := v[key].(string); ok {
+				return s
+			}
+		}
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		segments := len(v) > 0
+		for _, item := range v {
+			m, ok := item.(map[string]interface{})
+			if ok && m["type"] != nil {
+				parts = append(parts, genericText(m["text"]))
+				continue
+			}
+			segments = false
+			parts = append(parts, genericText(item))
+		}
+		if segments {
+			return strings.Join(parts, "")
+		}
+		return strings.Join(parts, ",")
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
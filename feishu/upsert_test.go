@@ -0,0 +1,229 @@
+package feishu_test
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"feishu_bitable_demo/feishu"
+)
+
+// record 构造一条 名称、数量 两个字段的输入记录
+func record(name string, quantity int) feishu.CreateRecordRequest {
+	return feishu.CreateRecordRequest{Fields: map[string]interface{}{"名称": name, "数量": quantity}}
+}
+
+func TestUpsert(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         []feishu.CreateRecordRequest
+		wantActions   []feishu.UpsertAction
+		wantRequests  int // 包括读取已有记录的一次请求
+		wantQuantity  map[string]float64
+		wantRemaining int
+	}{
+		{
+			name:          "全部新建",
+			input:         []feishu.CreateRecordRequest{record("记录4", 4), record("记录5", 5)},
+			wantActions:   []feishu.UpsertAction{feishu.UpsertCreated, feishu.UpsertCreated},
+			wantRequests:  2,
+			wantQuantity:  map[string]float64{"记录4": 4, "记录5": 5},
+			wantRemaining: 5,
+		},
+		{
+			name:          "全部一致时不写入",
+			input:         []feishu.CreateRecordRequest{record("记录1", 1), record("记录3", 3)},
+			wantActions:   []feishu.UpsertAction{feishu.UpsertUnchanged, feishu.UpsertUnchanged},
+			wantRequests:  1,
+			wantRemaining: 3,
+		},
+		{
+			name:          "新建、更新和不变混合",
+			input:         []feishu.CreateRecordRequest{record("记录1", 1), record("记录2", 20), record("记录9", 9)},
+			wantActions:   []feishu.UpsertAction{feishu.UpsertUnchanged, feishu.UpsertUpdated, feishu.UpsertCreated},
+			wantRequests:  3,
+			wantQuantity:  map[string]float64{"记录1": 1, "记录2": 20, "记录3": 3, "记录9": 9},
+			wantRemaining: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, appToken, tableID := newTestTable(t)
+			seeded, err := client.BatchCreateRecords(appToken, tableID, newRecords(3))
+			if err != nil {
+				t.Fatalf("BatchCreateRecords: %v", err)
+			}
+
+			before := srv.RequestCount()
+			result, err := client.Upsert(appToken, tableID, []string{"名称"}, tt.input)
+			if err != nil {
+				t.Fatalf("Upsert: %v", err)
+			}
+			if n := srv.RequestCount() - before; n != tt.wantRequests {
+				t.Errorf("sent %d requests, want %d", n, tt.wantRequests)
+			}
+
+			var created, updated, unchanged int
+			for i, action := range result.Actions {
+				if action != tt.wantActions[i] {
+					t.Errorf("Actions[%d] = %s, want %s", i, action, tt.wantActions[i])
+				}
+				switch action {
+				case feishu.UpsertCreated:
+					created++
+				case feishu.UpsertUpdated:
+					updated++
+				case feishu.UpsertUnchanged:
+					unchanged++
+				}
+				if result.RecordIDs[i] == "" {
+					t.Errorf("RecordIDs[%d] is empty", i)
+				}
+			}
+			if result.Created != created || result.Updated != updated || result.Unchanged != unchanged {
+				t.Errorf("counts = %d/%d/%d created/updated/unchanged, want %d/%d/%d",
+					result.Created, result.Updated, result.Unchanged, created, updated, unchanged)
+			}
+			// 已有记录沿用原来的 record_id
+			if tt.wantActions[0] != feishu.UpsertCreated && result.RecordIDs[0] != seeded.Records[0].ID {
+				t.Errorf("RecordIDs[0] = %s, want the existing record %s", result.RecordIDs[0], seeded.Records[0].ID)
+			}
+
+			records := srv.Records(appToken, tableID)
+			if len(records) != tt.wantRemaining {
+				t.Errorf("server has %d records, want %d", len(records), tt.wantRemaining)
+			}
+			for _, fields := range records {
+				name, _ := feishu.AsText(fields["名称"])
+				if want, ok := tt.wantQuantity[name]; ok && fields["数量"] != want {
+					t.Errorf("%s.数量 = %v, want %v", name, fields["数量"], want)
+				}
+			}
+		})
+	}
+}
+
+func TestUpsertRejectsInvalidKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyFields []string
+		input     []feishu.CreateRecordRequest
+	}{
+		{"没有键字段", nil, []feishu.CreateRecordRequest{record("记录1", 1)}},
+		{"缺少键字段", []string{"名称"}, []feishu.CreateRecordRequest{{Fields: map[string]interface{}{"数量": 1}}}},
+		{"输入中键重复", []string{"名称"}, []feishu.CreateRecordRequest{record("记录4", 4), record("记录4", 5)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, appToken, tableID := newTestTable(t)
+			before := srv.RequestCount()
+			if _, err := client.Upsert(appToken, tableID, tt.keyFields, tt.input); err == nil {
+				t.Fatal("Upsert: want error")
+			}
+			if n := srv.RequestCount() - before; n != 0 {
+				t.Errorf("sent %d requests, want 0", n)
+			}
+		})
+	}
+}
+
+func TestUpsertRejectsDuplicateRecordsInTable(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t)
+	if _, err := client.BatchCreateRecords(appToken, tableID, []feishu.CreateRecordRequest{record("记录1", 1), record("记录1", 2)}); err != nil {
+		t.Fatalf("BatchCreateRecords: %v", err)
+	}
+	if _, err := client.Upsert(appToken, tableID, []string{"名称"}, []feishu.CreateRecordRequest{record("记录1", 3), record("记录2", 2)}); err == nil {
+		t.Fatal("Upsert: want error")
+	}
+	if n := len(srv.Records(appToken, tableID)); n != 2 {
+		t.Errorf("server has %d records, want 2 (nothing written)", n)
+	}
+}
+
+func TestUpsertPartialFailure(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t, feishu.WithoutRetry())
+	seeded, err := client.BatchCreateRecords(appToken, tableID, newRecords(3))
+	if err != nil {
+		t.Fatalf("BatchCreateRecords: %v", err)
+	}
+	srv.FailNextOn(http.MethodPost, "records/batch_update", 1, http.StatusBadRequest, 1254001, "WrongRequestBody")
+
+	input := []feishu.CreateRecordRequest{record("记录1", 10), record("记录2", 2), record("记录4", 4)}
+	result, err := client.Upsert(appToken, tableID, []string{"名称"}, input)
+	var batchErr *feishu.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("err = %v, want *BatchError", err)
+	}
+
+	want := []feishu.UpsertAction{feishu.UpsertFailed, feishu.UpsertUnchanged, feishu.UpsertCreated}
+	if !reflect.DeepEqual(result.Actions, want) {
+		t.Errorf("Actions = %v, want %v", result.Actions, want)
+	}
+	if result.Created != 1 || result.Updated != 0 || result.Unchanged != 1 {
+		t.Errorf("counts = %d/%d/%d created/updated/unchanged, want 1/0/1", result.Created, result.Updated, result.Unchanged)
+	}
+	if result.RecordIDs[0] != seeded.Records[0].ID || result.RecordIDs[2] == "" {
+		t.Errorf("RecordIDs = %q, want the existing ID kept for the failed update", result.RecordIDs)
+	}
+	if quantity := srv.Records(appToken, tableID)[0]["数量"]; quantity != 1.0 {
+		t.Errorf("记录1.数量 = %v, want 1 (update failed)", quantity)
+	}
+}
+
+func TestUpsertFailedCreate(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t, feishu.WithoutRetry())
+	srv.FailNextOn(http.MethodPost, "records/batch_create", 1, http.StatusBadRequest, 1254001, "WrongRequestBody")
+
+	result, err := client.Upsert(appToken, tableID, []string{"名称"}, newRecords(2))
+	if err == nil {
+		t.Fatal("Upsert: want error")
+	}
+	want := []feishu.UpsertAction{feishu.UpsertFailed, feishu.UpsertFailed}
+	if !reflect.DeepEqual(result.Actions, want) || result.Created != 0 || result.RecordIDs[0] != "" {
+		t.Errorf("result = %+v, want both creates reported as failed", result)
+	}
+}
+
+func TestUpsertEmptyValuesUnchanged(t *testing.T) {
+	srv, client, appToken, tableID := newTestTable(t)
+	for _, spec := range []feishu.FieldSpec{
+		{Name: "上架", Type: feishu.FieldTypeCheckbox},
+		{Name: "备注", Type: feishu.FieldTypeText},
+		{Name: "标签", Type: feishu.FieldTypeMultiSelect, Property: feishu.SelectOptions("新品", "热销")},
+	} {
+		if _, err := client.CreateField(appToken, tableID, spec); err != nil {
+			t.Fatalf("CreateField: %v", err)
+		}
+	}
+
+	input := []feishu.CreateRecordRequest{{Fields: map[string]interface{}{
+		"名称": "苹果", "上架": false, "备注": "", "标签": []string{},
+	}}}
+	if _, err := client.Upsert(appToken, tableID, []string{"名称"}, input); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	// 再次写入相同的值：未勾选的复选框、空文本和空多选在读取时不返回，应视为未变化
+	before := srv.RequestCount()
+	result, err := client.Upsert(appToken, tableID, []string{"名称"}, input)
+	if err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if result.Unchanged != 1 || result.Updated != 0 {
+		t.Errorf("counts = %d updated, %d unchanged; want 0 updated, 1 unchanged", result.Updated, result.Unchanged)
+	}
+	if n := srv.RequestCount() - before; n != 1 {
+		t.Errorf("sent %d requests, want 1 (read only)", n)
+	}
+
+	// 勾选后再取消勾选仍会写入
+	input[0].Fields["上架"] = true
+	if result, err := client.Upsert(appToken, tableID, []string{"名称"}, input); err != nil || result.Updated != 1 {
+		t.Fatalf("Upsert checked = %+v, %v; want 1 updated", result, err)
+	}
+	input[0].Fields["上架"] = false
+	if result, err := client.Upsert(appToken, tableID, []string{"名称"}, input); err != nil || result.Updated != 1 {
+		t.Fatalf("Upsert unchecked = %+v, %v; want 1 updated", result, err)
+	}
+}
@@ -0,0 +1,71 @@
+package feishu
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Location 从飞书网页链接中解析出的资源标识，链接中没有的字段为空
+type Location struct {
+	AppToken    string // 多维表格 app_token，来自 /base/<app_token>
+	TableID     string // 数据表 table_id，来自 table 查询参数
+	ViewID      string // 视图 view_id，来自 view 查询参数
+	DocumentID  string // 云文档 document_id，来自 /docx/<document_id>
+	WikiToken   string // 知识库节点 token，来自 /wiki/<token>，需通过 GetWikiNode 换取实际的文档
+	FolderToken string // 云空间文件夹 token，来自 /drive/folder/<folder_token>
+}
+
+// IsURL 判断字符串是否为 http(s) 链接，用于区分用户粘贴的链接和直接填写的 token
+func IsURL(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")
+}
+
+// ParseURL 解析飞书网页链接，支持多维表格、云文档、知识库和云空间文件夹，例如：
+//
+//	https://xxx.feishu.cn/base/bascnxxxxxx?table=tblxxxxxx&view=vewxxxxxx
+//	https://xxx.feishu.cn/docx/doxcnxxxxxx
+//	https://xxx.feishu.cn/wiki/wikcnxxxxxx?table=tblxxxxxx
+//	https://xxx.feishu.cn/drive/folder/fldcnxxxxxx
+//
+// 知识库链接只能解析出节点 token，需要调用 ResolveURL 才能得到对应的 app_token 或 document_id。
+func ParseURL(raw string) (*Location, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("解析链接失败: %w", err)
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("不是有效的飞书链接: %s", raw)
+	}
+
+	loc := &Location{
+		TableID: u.Query().Get("table"),
+		ViewID:  u.Query().Get("view"),
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		token := segments[i+1]
+		switch segments[i] {
+		case "base":
+			loc.AppToken = token
+		case "docx":
+			loc.DocumentID = token
+		case "wiki":
+			loc.WikiToken = token
+		case "folder":
+			if i > 0 && segments[i-1] == "drive" {
+				loc.FolderToken = token
+			}
+		default:
+			continue
+		}
+		break
+	}
+
+	if loc.AppToken == "" && loc.DocumentID == "" && loc.WikiToken == "" && loc.FolderToken == "" {
+		return nil, fmt.Errorf("不支持的飞书链接（仅支持 /base/、/docx/、/wiki/ 和 /drive/folder/ 链接）: %s", raw)
+	}
+	return loc, nil
+}
@@ -0,0 +1,81 @@
+package feishu_test
+
+import (
+	"testing"
+
+	"feishu_bitable_demo/feishu"
+)
+
+func TestIsURL(t *testing.T) {
+	for s, want := range map[string]bool{
+		"https://xxx.feishu.cn/base/bascn1":  true,
+		" http://xxx.feishu.cn/docx/doxcn1 ": true,
+		"bascnAbCdEf":                        false,
+		"xxx.feishu.cn/base/bascn1":          false,
+		"ftp://xxx.feishu.cn/base/bascn1":    false,
+		"":                                   false,
+	} {
+		if got := feishu.IsURL(s); got != want {
+			t.Errorf("IsURL(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    feishu.Location
+		wantErr bool
+	}{
+		{name: "多维表格", raw: "https://xxx.feishu.cn/base/bascnAbc", want: feishu.Location{AppToken: "bascnAbc"}},
+		{
+			name: "多维表格带数据表和视图",
+			raw:  "https://xxx.feishu.cn/base/bascnAbc?table=tblDef&view=vewGhi",
+			want: feishu.Location{AppToken: "bascnAbc", TableID: "tblDef", ViewID: "vewGhi"},
+		},
+		{
+			name: "参数顺序和多余参数",
+			raw:  "https://xxx.feishu.cn/base/bascnAbc/?from=share&view=vewGhi&table=tblDef#anchor",
+			want: feishu.Location{AppToken: "bascnAbc", TableID: "tblDef", ViewID: "vewGhi"},
+		},
+		{name: "云文档", raw: "https://xxx.feishu.cn/docx/doxcnAbc", want: feishu.Location{DocumentID: "doxcnAbc"}},
+		{
+			name: "知识库",
+			raw:  "https://xxx.feishu.cn/wiki/wikcnAbc?table=tblDef",
+			want: feishu.Location{WikiToken: "wikcnAbc", TableID: "tblDef"},
+		},
+		{name: "云空间文件夹", raw: "https://xxx.feishu.cn/drive/folder/fldcnAbc", want: feishu.Location{FolderToken: "fldcnAbc"}},
+		{
+			name: "国际版",
+			raw:  "https://xxx.larksuite.com/base/bascnAbc?table=tblDef",
+			want: feishu.Location{AppToken: "bascnAbc", TableID: "tblDef"},
+		},
+		{name: "国际版云文档", raw: "https://xxx.sg.larksuite.com/docx/doxcnAbc", want: feishu.Location{DocumentID: "doxcnAbc"}},
+		{name: "首尾空白", raw: "  https://xxx.feishu.cn/docx/doxcnAbc\n", want: feishu.Location{DocumentID: "doxcnAbc"}},
+
+		{name: "不是链接", raw: "bascnAbc", wantErr: true},
+		{name: "缺少主机", raw: "https:///base/bascnAbc", wantErr: true},
+		{name: "缺少 token", raw: "https://xxx.feishu.cn/base/", wantErr: true},
+		{name: "不支持的类型", raw: "https://xxx.feishu.cn/sheets/shtcnAbc", wantErr: true},
+		{name: "不是云空间文件夹", raw: "https://xxx.feishu.cn/folder/fldcnAbc", wantErr: true},
+		{name: "格式错误", raw: "https://xxx.feishu.cn/base/%zz", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := feishu.ParseURL(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseURL(%q) = %+v, want error", tt.raw, loc)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseURL(%q): %v", tt.raw, err)
+			}
+			if *loc != tt.want {
+				t.Errorf("ParseURL(%q) = %+v, want %+v", tt.raw, *loc, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package feishu
+
+import (
+	"context"
+	"fmt"
+
+	larkbitable "github.com/larksuite/oapi-sdk-go/v3/service/bitable/v1"
+)
+
+// 视图类型
+const (
+	ViewTypeGrid    = "grid"    // 表格视图
+	ViewTypeKanban  = "kanban"  // 看板视图
+	ViewTypeGallery = "gallery" // 画册视图
+	ViewTypeGantt   = "gantt"   // 甘特视图
+	ViewTypeForm    = "form"    // 表单视图
+)
+
+// View 数据表中的一个视图
+type View struct {
+	ID   string `json:"view_id"`
+	Name string `json:"view_name"`
+	Type string `json:"view_type"` // 见 ViewTypeGrid 等常量
+}
+
+// newView 将 SDK 返回的视图转换为 View
+func newView(v *larkbitable.AppTableView) *View {
+	return &View{
+		ID:   StringValue(v.ViewId),
+		Name: StringValue(v.ViewName),
+		Type: StringValue(v.ViewType),
+	}
+}
+
+// ListViews 列出数据表中的所有视图，自动翻页
+func (c *MultiTableClient) ListViews(appToken, tableID string) ([]*View, error) {
+	return c.ListViewsContext(context.Background(), appToken, tableID)
+}
+
+// ListViewsContext 列出数据表中的所有视图（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) ListViewsContext(ctx context.Context, appToken, tableID string) ([]*View, error) {
+	var (
+		views     []*View
+		pageToken string
+	)
+	for {
+		builder := larkbitable.NewListAppTableViewReqBuilder().
+			AppToken(appToken).
+			TableId(tableID).
+			PageSize(DefaultPageSize)
+		if pageToken != "" {
+			builder.PageToken(pageToken)
+		}
+		req := builder.Build()
+
+		var resp *larkbitable.ListAppTableViewResp
+		err := c.do(ctx, EndpointDefault, true, func() error {
+			var err error
+			resp, err = c.client.Bitable.AppTableView.List(ctx, req)
+			if err != nil {
+				return fmt.Errorf("列出视图失败: %w", err)
+			}
+			if !resp.Success() {
+				return newAPIError("列出视图", resp.ApiResp, resp.CodeError)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range resp.Data.Items {
+			views = append(views, newView(item))
+		}
+		pageToken = StringValue(resp.Data.PageToken)
+		if resp.Data.HasMore == nil || !*resp.Data.HasMore || pageToken == "" {
+			return views, nil
+		}
+	}
+}
+
+// CreateView 在数据表中新增视图，viewType 为 ViewTypeGrid 等常量，为空时创建表格视图
+func (c *MultiTableClient) CreateView(appToken, tableID, name, viewType string) (*View, error) {
+	return c.CreateViewContext(context.Background(), appToken, tableID, name, viewType)
+}
+
+// CreateViewContext 新增视图（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) CreateViewContext(ctx context.Context, appToken, tableID, name, viewType string) (*View, error) {
+	view := larkbitable.NewReqViewBuilder().ViewName(name)
+	if viewType != "" {
+		view.ViewType(viewType)
+	}
+	req := larkbitable.NewCreateAppTableViewReqBuilder().
+		AppToken(appToken).
+		TableId(tableID).
+		ReqView(view.Build()).
+		Build()
+
+	var resp *larkbitable.CreateAppTableViewResp
+	err := c.do(ctx, EndpointDefault, false, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTableView.Create(ctx, req)
+		if err != nil {
+			return fmt.Errorf("创建视图失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("创建视图", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newView(resp.Data.View), nil
+}
+
+// RenameView 修改视图名称
+func (c *MultiTableClient) RenameView(appToken, tableID, viewID, name string) (*View, error) {
+	return c.RenameViewContext(context.Background(), appToken, tableID, viewID, name)
+}
+
+// RenameViewContext 修改视图名称（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) RenameViewContext(ctx context.Context, appToken, tableID, viewID, name string) (*View, error) {
+	req := larkbitable.NewPatchAppTableViewReqBuilder().
+		AppToken(appToken).
+		TableId(tableID).
+		ViewId(viewID).
+		Body(larkbitable.NewPatchAppTableViewReqBodyBuilder().ViewName(name).Build()).
+		Build()
+
+	var resp *larkbitable.PatchAppTableViewResp
+	err := c.do(ctx, EndpointDefault, true, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTableView.Patch(ctx, req)
+		if err != nil {
+			return fmt.Errorf("修改视图失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("修改视图", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newView(resp.Data.View), nil
+}
+
+// DeleteView 删除视图，数据表中的最后一个视图不能删除
+func (c *MultiTableClient) DeleteView(appToken, tableID, viewID string) error {
+	return c.DeleteViewContext(context.Background(), appToken, tableID, viewID)
+}
+
+// DeleteViewContext 删除视图（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) DeleteViewContext(ctx context.Context, appToken, tableID, viewID string) error {
+	req := larkbitable.NewDeleteAppTableViewReqBuilder().
+		AppToken(appToken).
+		TableId(tableID).
+		ViewId(viewID).
+		Build()
+
+	var resp *larkbitable.DeleteAppTableViewResp
+	err := c.do(ctx, EndpointDefault, true, func() error {
+		var err error
+		resp, err = c.client.Bitable.AppTableView.Delete(ctx, req)
+		if err != nil {
+			return fmt.Errorf("删除视图失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("删除视图", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package feishu
+
+import (
+	"context"
+	"fmt"
+
+	larkwiki "github.com/larksuite/oapi-sdk-go/v3/service/wiki/v2"
+)
+
+// 知识库节点的文档类型
+const (
+	WikiObjTypeDocx    = "docx"
+	WikiObjTypeBitable = "bitable"
+)
+
+// WikiNode 知识库节点
+type WikiNode struct {
+	NodeToken string // 节点 token，即 /wiki/<token> 中的值
+	ObjType   string // 节点对应的文档类型，如 docx、bitable、sheet
+	ObjToken  string // 文档的实际 token，多维表格为 app_token，云文档为 document_id
+	Title     string
+}
+
+// GetWikiNode 获取知识库节点信息
+func (c *MultiTableClient) GetWikiNode(token string) (*WikiNode, error) {
+	return c.GetWikiNodeContext(context.Background(), token)
+}
+
+// GetWikiNodeContext 获取知识库节点信息（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) GetWikiNodeContext(ctx context.Context, token string) (*WikiNode, error) {
+	req := larkwiki.NewGetNodeSpaceReqBuilder().
+		Token(token).
+		Build()
+
+	var resp *larkwiki.GetNodeSpaceResp
+	err := c.do(ctx, EndpointDefault, true, func() error {
+		var err error
+		resp, err = c.client.Wiki.Space.GetNode(ctx, req)
+		if err != nil {
+			return fmt.Errorf("获取知识库节点失败: %w", err)
+		}
+		if !resp.Success() {
+			return newAPIError("获取知识库节点", resp.ApiResp, resp.CodeError)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	node := resp.Data.Node
+	return &WikiNode{
+		NodeToken: token,
+		ObjType:   StringValue(node.ObjType),
+		ObjToken:  StringValue(node.ObjToken),
+		Title:     StringValue(node.Title),
+	}, nil
+}
+
+// ResolveURL 解析飞书网页链接，知识库链接会转换为节点对应的多维表格 app_token 或云文档 document_id
+func (c *MultiTableClient) ResolveURL(raw string) (*Location, error) {
+	return c.ResolveURLContext(context.Background(), raw)
+}
+
+// ResolveURLContext 解析飞书网页链接（支持通过 ctx 取消和设置超时）
+func (c *MultiTableClient) ResolveURLContext(ctx context.Context, raw string) (*Location, error) {
+	loc, err := ParseURL(raw)
+	if err != nil {
+		return nil, err
+	}
+	if loc.WikiToken == "" {
+		return loc, nil
+	}
+
+	node, err := c.GetWikiNodeContext(ctx, loc.WikiToken)
+	if err != nil {
+		return nil, err
+	}
+	switch node.ObjType {
+	case WikiObjTypeBitable:
+		loc.AppToken = node.ObjToken
+	case WikiObjTypeDocx:
+		loc.DocumentID = node.ObjToken
+	default:
+		return nil, fmt.Errorf("知识库节点 %s 的文档类型 %q 暂不支持", loc.WikiToken, node.ObjType)
+	}
+	return loc, nil
+}
@@ -0,0 +1,87 @@
+package feishu_test
+
+import (
+	"errors"
+	"testing"
+
+	"feishu_bitable_demo/feishu"
+	"feishu_bitable_demo/feishu/fakeserver"
+)
+
+func TestResolveURL(t *testing.T) {
+	srv := fakeserver.New()
+	t.Cleanup(srv.Close)
+	client := srv.NewClient()
+	bitableNode := srv.AddWikiNode(feishu.WikiObjTypeBitable, "bascnWiki", "产品库")
+	docxNode := srv.AddWikiNode(feishu.WikiObjTypeDocx, "doxcnWiki", "说明文档")
+	sheetNode := srv.AddWikiNode("sheet", "shtcnWiki", "电子表格")
+
+	tests := []struct {
+		name    string
+		raw     string
+		want    feishu.Location
+		wantErr bool
+	}{
+		{
+			name: "多维表格链接不调用接口",
+			raw:  "https://xxx.feishu.cn/base/bascnAbc?table=tblDef",
+			want: feishu.Location{AppToken: "bascnAbc", TableID: "tblDef"},
+		},
+		{
+			name: "知识库中的多维表格",
+			raw:  "https://xxx.feishu.cn/wiki/" + bitableNode + "?table=tblDef&view=vewGhi",
+			want: feishu.Location{WikiToken: bitableNode, AppToken: "bascnWiki", TableID: "tblDef", ViewID: "vewGhi"},
+		},
+		{
+			name: "知识库中的云文档",
+			raw:  "https://xxx.larksuite.com/wiki/" + docxNode,
+			want: feishu.Location{WikiToken: docxNode, DocumentID: "doxcnWiki"},
+		},
+		{name: "不支持的文档类型", raw: "https://xxx.feishu.cn/wiki/" + sheetNode, wantErr: true},
+		{name: "节点不存在", raw: "https://xxx.feishu.cn/wiki/wikcnMissing", wantErr: true},
+		{name: "无法解析的链接", raw: "https://xxx.feishu.cn/sheets/shtcnAbc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := client.ResolveURL(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ResolveURL(%q) = %+v, want error", tt.raw, loc)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveURL(%q): %v", tt.raw, err)
+			}
+			if *loc != tt.want {
+				t.Errorf("ResolveURL(%q) = %+v, want %+v", tt.raw, *loc, tt.want)
+			}
+		})
+	}
+
+	before := srv.RequestCount()
+	if _, err := client.ResolveURL("https://xxx.feishu.cn/base/bascnAbc"); err != nil {
+		t.Fatal(err)
+	}
+	if n := srv.RequestCount() - before; n != 0 {
+		t.Errorf("resolving a base link sent %d requests, want 0", n)
+	}
+	if _, err := client.ResolveURL("https://xxx.feishu.cn/wiki/wikcnMissing"); !errors.Is(err, feishu.ErrNotFound) {
+		t.Errorf("missing wiki node: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetWikiNode(t *testing.T) {
+	srv := fakeserver.New()
+	t.Cleanup(srv.Close)
+	token := srv.AddWikiNode(feishu.WikiObjTypeDocx, "doxcnWiki", "说明文档")
+
+	node, err := srv.NewClient().GetWikiNode(token)
+	if err != nil {
+		t.Fatalf("GetWikiNode: %v", err)
+	}
+	want := feishu.WikiNode{NodeToken: token, ObjType: feishu.WikiObjTypeDocx, ObjToken: "doxcnWiki", Title: "说明文档"}
+	if *node != want {
+		t.Errorf("GetWikiNode = %+v, want %+v", *node, want)
+	}
+}
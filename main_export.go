@@ -1,153 +0,0 @@
-package main
-
-import (
-	"fmt"
-	"log"
-	"time"
-
-	"feishu_bitable_demo/feishu"
-
-	"gopkg.in/yaml.v3"
-	"os"
-)
-
-// ExportConfig 配置结构
-type ExportConfig struct {
-	Feishu struct {
-		AppID       string `yaml:"app_id"`
-		AppSecret   string `yaml:"app_secret"`
-		AppToken    string `yaml:"app_token"`    // 多维表格 app_token
-		TableID     string `yaml:"table_id"`     // 多维表格 table_id
-		FolderToken string `yaml:"folder_token"` // 云文档文件夹 token
-	} `yaml:"feishu"`
-}
-
-func main() {
-	// 读取配置文件
-	config, err := loadExportConfig("config.yaml")
-	if err != nil {
-		log.Fatalf("❌ 读取配置文件失败: %v", err)
-	}
-
-	// 验证配置
-	if config.Feishu.AppID == "your_app_id_here" || config.Feishu.AppSecret == "your_app_secret_here" {
-		log.Fatalf("❌ 请先在 config.yaml 中填写正确的飞书应用配置")
-	}
-
-	// 创建飞书客户端
-	client := feishu.NewMultiTableClient(config.Feishu.AppID, config.Feishu.AppSecret)
-
-	fmt.Println("=================================================")
-	fmt.Println("📊 飞书数据导出示例：多维表格 → 云文档")
-	fmt.Println("=================================================\n")
-
-	// 步骤 1: 从多维表格读取数据
-	fmt.Println("📝 步骤 1: 从多维表格读取数据")
-	records, err := fetchTableData(client, config.Feishu.AppToken, config.Feishu.TableID)
-	if err != nil {
-		log.Fatalf("❌ 读取表格数据失败: %v", err)
-	}
-	fmt.Printf("✅ 成功读取 %d 条记录\n\n", len(records))
-
-	// 步骤 2: 创建云文档
-	fmt.Println("📝 步骤 2: 创建云文档")
-	documentID, err := createReportDocument(client, config.Feishu.FolderToken)
-	if err != nil {
-		log.Fatalf("❌ 创建云文档失败: %v", err)
-	}
-	fmt.Printf("✅ 成功创建云文档，Document ID: %s\n\n", documentID)
-
-	// 等待一秒
-	time.Sleep(1 * time.Second)
-
-	fmt.Println("📝 步骤 3: 将数据导出到云文档")
-	err = exportDataToDocument(client, documentID, records)
-	if err != nil {
-		log.Fatalf("❌ 导出数据失败: %v", err)
-	}
-	fmt.Println("✅ 成功将数据导出到云文档\n")
-
-	fmt.Println("=================================================")
-	fmt.Println("🎉 数据导出完成！")
-	fmt.Printf("📄 文档链接: https://example.feishu.cn/docx/%s\n", documentID)
-	fmt.Println("=================================================")
-	fmt.Println("\n💡 提示：")
-	fmt.Println("  - 数据已成功导出到云文档")
-	fmt.Println("  - 你可以在飞书中查看导出的报告")
-	fmt.Println("  - 文档包含了表格数据的详细信息和统计")
-}
-
-// TableRecord 简化的记录结构
-type TableRecord struct {
-	RecordID string
-	Fields   map[string]interface{}
-}
-
-// fetchTableData 从多维表格读取数据
-func fetchTableData(client *feishu.MultiTableClient, appToken, tableID string) ([]TableRecord, error) {
-	records, err := client.ListRecords(appToken, tableID, 100)
-	if err != nil {
-		return nil, err
-	}
-
-	var result []TableRecord
-	for _, record := range records.Data.Items {
-		result = append(result, TableRecord{
-			RecordID: *record.RecordId,
-			Fields:   record.Fields,
-		})
-	}
-
-	fmt.Printf("  📊 读取到 %d 条记录\n", len(result))
-	return result, nil
-}
-
-// createReportDocument 创建报告文档
-func createReportDocument(client *feishu.MultiTableClient, folderToken string) (string, error) {
-	title := fmt.Sprintf("数据报告 - %s", time.Now().Format("2006-01-02 15:04:05"))
-
-	resp, err := client.CreateDocument(title, folderToken)
-	if err != nil {
-		return "", err
-	}
-
-	if resp.Data == nil || resp.Data.Document == nil {
-		return "", fmt.Errorf("响应数据为空")
-	}
-
-	documentID := *resp.Data.Document.DocumentId
-	fmt.Printf("  📄 文档标题: %s\n", title)
-	fmt.Printf("  📋 文档 ID: %s\n", documentID)
-
-	return documentID, nil
-}
-
-// exportDataToDocument 将数据导出到云文档（简化版）
-func exportDataToDocument(client *feishu.MultiTableClient, documentID string, records []TableRecord) error {
-	fmt.Printf("  📊 准备导出 %d 条记录到云文档\n", len(records))
-	fmt.Println("  ℹ️  由于SDK API限制，本示例仅创建文档框架")
-	fmt.Println("  💡 你可以手动在飞书中编辑文档内容")
-	
-	// 注意：由于飞书 SDK 对块编辑的 API 较为复杂，这里仅作为示例
-	// 实际应用中，可以通过其他方式（如飞书机器人、Webhook等）来更新文档内容
-	
-	fmt.Printf("  ✓ 文档已创建，包含 %d 条记录的元数据\n", len(records))
-	
-	return nil
-}
-
-// loadExportConfig 加载配置文件
-func loadExportConfig(filename string) (*ExportConfig, error) {
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, err
-	}
-
-	var config ExportConfig
-	err = yaml.Unmarshal(data, &config)
-	if err != nil {
-		return nil, err
-	}
-
-	return &config, nil
-}
@@ -26,7 +26,7 @@ echo ""
 
 # 编译项目
 echo "🔨 正在编译项目..."
-go build -o feishu_test main.go
+go build -o feishu ./cmd/feishu
 echo "✅ 编译完成"
 echo ""
 
@@ -47,7 +47,7 @@ fi
 # 运行测试
 echo "🧪 运行测试程序..."
 echo ""
-./feishu_test
+./feishu records demo "$@"
 
 echo ""
 echo "=================================================="
@@ -25,4 +25,4 @@ if grep -q "你的app_id" config.yaml || grep -q "your_app_id_here" config.yaml;
 fi
 
 # 运行程序
-go run main_markdown.go
+go run ./cmd/feishu docs styles "$@"
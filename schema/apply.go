@@ -0,0 +1,113 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"feishu_bitable_demo/feishu"
+)
+
+// ApplyOptions 执行计划的选项
+type ApplyOptions struct {
+	AllowDestructive bool          // 执行破坏性变更，为 false 时跳过这些变更
+	OnChange         func(*Change) // 每项变更执行完成后调用，可用于输出进度
+}
+
+// ApplyResult 执行计划的结果
+type ApplyResult struct {
+	Applied []*Change // 已执行的变更
+	Skipped []*Change // 因未允许破坏性变更而跳过的变更
+}
+
+// Apply 按顺序执行计划中的变更，遇到错误时停止并返回已执行的部分。
+// 计划生成后线上结构又发生变化时应重新调用 Diff。
+func Apply(client *feishu.MultiTableClient, plan *Plan, opts ApplyOptions) (*ApplyResult, error) {
+	return ApplyContext(context.Background(), client, plan, opts)
+}
+
+// ApplyContext 执行计划（支持通过 ctx 取消和设置超时）
+func ApplyContext(ctx context.Context, client *feishu.MultiTableClient, plan *Plan, opts ApplyOptions) (*ApplyResult, error) {
+	result := &ApplyResult{}
+	for _, c := range plan.Changes {
+		if c.Destructive && !opts.AllowDestructive {
+			result.Skipped = append(result.Skipped, c)
+			continue
+		}
+		if err := applyChange(ctx, client, plan.AppToken, c); err != nil {
+			return result, fmt.Errorf("%s: %w", c, err)
+		}
+		result.Applied = append(result.Applied, c)
+		if opts.OnChange != nil {
+			opts.OnChange(c)
+		}
+	}
+	return result, nil
+}
+
+func applyChange(ctx context.Context, client *feishu.MultiTableClient, appToken string, c *Change) error {
+	switch c.Kind {
+	case KindTable:
+		if c.Action == ActionCreate {
+			return createTable(ctx, client, appToken, c.table)
+		}
+		return client.DeleteTableContext(ctx, appToken, c.tableID)
+	case KindField:
+		var err error
+		switch c.Action {
+		case ActionCreate:
+			_, err = client.CreateFieldContext(ctx, appToken, c.tableID, c.spec)
+		case ActionUpdate:
+			_, err = client.UpdateFieldContext(ctx, appToken, c.tableID, c.id, c.spec)
+		case ActionDelete:
+			err = client.DeleteFieldContext(ctx, appToken, c.tableID, c.id)
+		}
+		return err
+	case KindView:
+		switch c.Action {
+		case ActionCreate:
+			_, err := client.CreateViewContext(ctx, appToken, c.tableID, c.Name, c.viewType)
+			return err
+		case ActionUpdate:
+			// 视图类型不能修改，先将原视图改名，创建同名的新视图后再删除原视图，
+			// 避免原视图是数据表中唯一的视图时无法删除
+			if _, err := client.RenameViewContext(ctx, appToken, c.tableID, c.id, c.Name+"_"+c.id); err != nil {
+				return err
+			}
+			if _, err := client.CreateViewContext(ctx, appToken, c.tableID, c.Name, c.viewType); err != nil {
+				return err
+			}
+		}
+		return client.DeleteViewContext(ctx, appToken, c.tableID, c.id)
+	}
+	return fmt.Errorf("未知的变更类型 %s", c.Kind)
+}
+
+// createTable 创建数据表；声明了视图时创建这些视图，并删除自动创建的默认视图
+func createTable(ctx context.Context, client *feishu.MultiTableClient, appToken string, t *Table) error {
+	specs := make([]feishu.FieldSpec, 0, len(t.Fields))
+	for _, f := range t.Fields {
+		spec, err := f.spec()
+		if err != nil {
+			return err
+		}
+		specs = append(specs, spec)
+	}
+	tableID, err := client.CreateTableContext(ctx, appToken, t.Name, feishu.Headers(specs...))
+	if err != nil {
+		return err
+	}
+	if len(t.Views) == 0 {
+		return nil
+	}
+
+	views, err := client.ListViewsContext(ctx, appToken, tableID)
+	if err != nil {
+		return err
+	}
+	for _, change := range diffViews(t, tableID, views) {
+		if err := applyChange(ctx, client, appToken, change); err != nil {
+			return fmt.Errorf("%s: %w", change, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"feishu_bitable_demo/feishu"
+)
+
+// applyAndDiff 执行 schema 的变更计划，返回执行结果以及执行后重新对比得到的计划
+func applyAndDiff(t *testing.T, client *feishu.MultiTableClient, appToken, data string, opts ApplyOptions) (*ApplyResult, *Plan) {
+	t.Helper()
+	s := mustParse(t, data)
+	plan, err := Diff(client, appToken, s)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	result, err := Apply(client, plan, opts)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	after, err := Diff(client, appToken, s)
+	if err != nil {
+		t.Fatalf("Diff after Apply: %v", err)
+	}
+	return result, after
+}
+
+func listViews(t *testing.T, client *feishu.MultiTableClient, appToken, tableID string) []string {
+	t.Helper()
+	views, err := client.ListViews(appToken, tableID)
+	if err != nil {
+		t.Fatalf("ListViews: %v", err)
+	}
+	names := make([]string, 0, len(views))
+	for _, v := range views {
+		names = append(names, v.Name+":"+v.Type)
+	}
+	return names
+}
+
+func findTable(t *testing.T, client *feishu.MultiTableClient, appToken, name string) string {
+	t.Helper()
+	tables, err := client.ListTables(appToken)
+	if err != nil {
+		t.Fatalf("ListTables: %v", err)
+	}
+	for _, table := range tables {
+		if *table.Name == name {
+			return *table.TableId
+		}
+	}
+	t.Fatalf("数据表 %s 不存在", name)
+	return ""
+}
+
+func TestApply(t *testing.T) {
+	_, client, appToken, tableID := newProductApp(t)
+	schema := `
+tables:
+  - name: 产品列表
+    fields:
+      - name: 名称
+        type: text
+      - name: 状态
+        type: single_select
+        options: [在售, 预售, 下架]
+      - name: 价格
+        type: number
+        ui_type: Currency
+        currency_code: CNY
+        formatter: "0.00"
+      - name: 上架日期
+        type: date
+        date_format: yyyy/MM/dd
+    views:
+      - name: 表格
+      - name: 按状态
+        type: kanban
+  - name: 订单
+    fields:
+      - name: 订单号
+        type: text
+      - name: 数量
+        type: number
+    views:
+      - name: 全部订单
+`
+	result, after := applyAndDiff(t, client, appToken, schema, ApplyOptions{AllowDestructive: true})
+	if len(result.Skipped) != 0 {
+		t.Errorf("skipped = %v, want none", result.Skipped)
+	}
+	if len(after.Changes) != 0 {
+		t.Fatalf("changes after Apply = %q, want none", changeStrings(after))
+	}
+
+	fields, err := client.ListFields(appToken, tableID)
+	if err != nil {
+		t.Fatalf("ListFields: %v", err)
+	}
+	var names []string
+	for _, f := range fields {
+		names = append(names, f.Name)
+	}
+	if want := []string{"名称", "状态", "价格", "上架日期"}; !slices.Equal(names, want) {
+		t.Errorf("fields = %q, want %q", names, want)
+	}
+	if got, want := listViews(t, client, appToken, tableID), []string{"表格:grid", "按状态:kanban"}; !slices.Equal(got, want) {
+		t.Errorf("产品列表 views = %q, want %q", got, want)
+	}
+	orders := findTable(t, client, appToken, "订单")
+	if got, want := listViews(t, client, appToken, orders), []string{"全部订单:grid"}; !slices.Equal(got, want) {
+		t.Errorf("订单 views = %q, want %q", got, want)
+	}
+}
+
+func TestApplyKeepsOptionIDs(t *testing.T) {
+	_, client, appToken, tableID := newProductApp(t)
+	optionIDs := func() map[string]string {
+		fields, err := client.ListFields(appToken, tableID)
+		if err != nil {
+			t.Fatalf("ListFields: %v", err)
+		}
+		ids := make(map[string]string)
+		for _, f := range fields {
+			if f.Name == "状态" {
+				for _, option := range f.Property.Options {
+					ids[*option.Name] = *option.Id
+				}
+			}
+		}
+		return ids
+	}
+	before := optionIDs()
+
+	_, after := applyAndDiff(t, client, appToken, strings.Replace(productSchema, "[在售, 下架]", "[预售, 在售, 下架]", 1), ApplyOptions{})
+	if len(after.Changes) != 0 {
+		t.Fatalf("changes after Apply = %q, want none", changeStrings(after))
+	}
+	ids := optionIDs()
+	for name, id := range before {
+		if ids[name] != id {
+			t.Errorf("option %s id = %q, want %q", name, ids[name], id)
+		}
+	}
+	if ids["预售"] == "" {
+		t.Errorf("option 预售 not created: %v", ids)
+	}
+}
+
+func TestApplySkipsDestructive(t *testing.T) {
+	_, client, appToken, tableID := newProductApp(t)
+	schema := `
+tables:
+  - name: 产品列表
+    fields:
+      - name: 产品名称
+        type: text
+      - name: 状态
+        type: single_select
+        options: [在售]
+      - name: 价格
+        type: text
+      - name: 库存
+        type: number
+    views:
+      - name: 表格
+        type: kanban
+  - name: 订单
+    fields:
+      - name: 订单号
+        type: text
+`
+	result, after := applyAndDiff(t, client, appToken, schema, ApplyOptions{})
+
+	var applied, skipped []string
+	for _, c := range result.Applied {
+		applied = append(applied, c.String())
+	}
+	for _, c := range result.Skipped {
+		skipped = append(skipped, c.String())
+	}
+	if want := []string{"+ 字段 产品列表.库存", "+ 数据表 订单"}; !slices.Equal(applied, want) {
+		t.Errorf("applied = %q, want %q", applied, want)
+	}
+	wantSkipped := []string{
+		"~ 字段 产品列表.状态（破坏性）",
+		"~ 字段 产品列表.价格（破坏性）",
+		"- 字段 产品列表.备注（破坏性）",
+		"~ 视图 产品列表.表格（破坏性）",
+	}
+	if !slices.Equal(skipped, wantSkipped) {
+		t.Errorf("skipped = %q, want %q", skipped, wantSkipped)
+	}
+	if got := changeStrings(after); !slices.Equal(got, wantSkipped) {
+		t.Errorf("changes after Apply = %q, want %q", got, wantSkipped)
+	}
+
+	fields, err := client.ListFields(appToken, tableID)
+	if err != nil {
+		t.Fatalf("ListFields: %v", err)
+	}
+	for _, f := range fields {
+		if f.Name == "价格" && f.Type != feishu.FieldTypeNumber {
+			t.Errorf("价格 type = %v, want unchanged", f.Type)
+		}
+	}
+	if len(fields) != 5 {
+		t.Errorf("len(fields) = %d, want 5", len(fields))
+	}
+}
+
+func TestApplyRecreatesOnlyView(t *testing.T) {
+	_, client, appToken, tableID := newProductApp(t)
+	_, after := applyAndDiff(t, client, appToken, productSchema+"    views:\n      - name: 表格\n        type: kanban\n", ApplyOptions{AllowDestructive: true})
+	if len(after.Changes) != 0 {
+		t.Fatalf("changes after Apply = %q, want none", changeStrings(after))
+	}
+	if got, want := listViews(t, client, appToken, tableID), []string{"表格:kanban"}; !slices.Equal(got, want) {
+		t.Errorf("views = %q, want %q", got, want)
+	}
+}
+
+func TestApplyCreateTableWithDefaultViewName(t *testing.T) {
+	_, client, appToken, _ := newProductApp(t)
+	schema := productSchema + `
+  - name: 订单
+    fields:
+      - name: 订单号
+        type: text
+    views:
+      - name: 表格
+        type: gallery
+`
+	_, after := applyAndDiff(t, client, appToken, schema, ApplyOptions{})
+	if len(after.Changes) != 0 {
+		t.Fatalf("changes after Apply = %q, want none", changeStrings(after))
+	}
+	orders := findTable(t, client, appToken, "订单")
+	if got, want := listViews(t, client, appToken, orders), []string{"表格:gallery"}; !slices.Equal(got, want) {
+		t.Errorf("views = %q, want %q", got, want)
+	}
+}
+
+func TestApplyManyTables(t *testing.T) {
+	_, client, appToken, _ := newProductApp(t)
+	for i := 1; i <= 24; i++ {
+		if _, err := client.CreateTable(appToken, fmt.Sprintf("数据表%02d", i), nil); err != nil {
+			t.Fatalf("CreateTable: %v", err)
+		}
+	}
+
+	result, after := applyAndDiff(t, client, appToken, productSchema, ApplyOptions{AllowDestructive: true})
+	if len(result.Applied) != 24 {
+		t.Errorf("applied %d changes, want 24", len(result.Applied))
+	}
+	if len(after.Changes) != 0 {
+		t.Fatalf("changes after Apply = %q, want none", changeStrings(after))
+	}
+	tables, err := client.ListTables(appToken)
+	if err != nil {
+		t.Fatalf("ListTables: %v", err)
+	}
+	if len(tables) != 1 || *tables[0].Name != "产品列表" {
+		t.Errorf("tables = %d, want only 产品列表", len(tables))
+	}
+}
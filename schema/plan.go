@@ -0,0 +1,453 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"feishu_bitable_demo/feishu"
+)
+
+// Action 变更的操作
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Kind 变更的对象
+type Kind string
+
+const (
+	KindTable Kind = "table"
+	KindField Kind = "field"
+	KindView  Kind = "view"
+)
+
+// Change 计划中的一项变更
+type Change struct {
+	Action      Action
+	Kind        Kind
+	Table       string   // 数据表名称
+	Name        string   // 字段或视图名称，Kind 为 KindTable 时与 Table 相同
+	Details     []string // 变更内容，如 "类型: 文本 → 数字"
+	Destructive bool     // 是否可能丢失数据或配置，如删除字段、修改字段类型、删除选项
+
+	tableID  string           // 线上数据表的 table_id，新建数据表时为空
+	id       string           // 线上字段的 field_id 或视图的 view_id
+	table    *Table           // 新建的数据表
+	spec     feishu.FieldSpec // 新建或更新字段时的字段定义
+	viewType string           // 新建或重建视图时的视图类型
+}
+
+var (
+	actionSymbols = map[Action]string{ActionCreate: "+", ActionUpdate: "~", ActionDelete: "-"}
+	kindNames     = map[Kind]string{KindTable: "数据表", KindField: "字段", KindView: "视图"}
+)
+
+// String 返回变更的摘要，如 "+ 字段 产品列表.折扣" 或 "- 数据表 临时（破坏性）"
+func (c *Change) String() string {
+	path := c.Table
+	if c.Kind != KindTable {
+		path += "." + c.Name
+	}
+	s := actionSymbols[c.Action] + " " + kindNames[c.Kind] + " " + path
+	if c.Destructive {
+		s += "（破坏性）"
+	}
+	return s
+}
+
+// Plan 使线上多维表格与 schema 一致所需的变更，按执行顺序排列
+type Plan struct {
+	AppToken string
+	Changes  []*Change
+}
+
+// Destructive 返回计划中的破坏性变更
+func (p *Plan) Destructive() []*Change {
+	var changes []*Change
+	for _, c := range p.Changes {
+		if c.Destructive {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// String 列出所有变更及其内容，最后一行为统计
+func (p *Plan) String() string {
+	if len(p.Changes) == 0 {
+		return "没有变更，多维表格的结构与 schema 一致\n"
+	}
+	var b strings.Builder
+	counts := make(map[Action]int)
+	for _, c := range p.Changes {
+		counts[c.Action]++
+		b.WriteString(c.String() + "\n")
+		for _, detail := range c.Details {
+			b.WriteString("    " + detail + "\n")
+		}
+	}
+	fmt.Fprintf(&b, "\n共 %d 项变更：新增 %d，修改 %d，删除 %d",
+		len(p.Changes), counts[ActionCreate], counts[ActionUpdate], counts[ActionDelete])
+	if n := len(p.Destructive()); n > 0 {
+		fmt.Fprintf(&b, "；其中 %d 项为破坏性变更", n)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// Diff 对比 schema 与 appToken 对应的多维表格，生成变更计划。
+// 数据表、字段、视图按名称对应，schema 中每张数据表的第一个字段对应索引列。
+func Diff(client *feishu.MultiTableClient, appToken string, s *Schema) (*Plan, error) {
+	return DiffContext(context.Background(), client, appToken, s)
+}
+
+// DiffContext 生成变更计划（支持通过 ctx 取消和设置超时）
+func DiffContext(ctx context.Context, client *feishu.MultiTableClient, appToken string, s *Schema) (*Plan, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	tables, err := client.ListTablesContext(ctx, appToken)
+	if err != nil {
+		return nil, err
+	}
+	live := make(map[string]string, len(tables))
+	for _, t := range tables {
+		live[feishu.StringValue(t.Name)] = feishu.StringValue(t.TableId)
+	}
+
+	plan := &Plan{AppToken: appToken}
+	declared := make(map[string]bool, len(s.Tables))
+	for _, t := range s.Tables {
+		declared[t.Name] = true
+		tableID, ok := live[t.Name]
+		if !ok {
+			plan.Changes = append(plan.Changes, createTableChange(t))
+			continue
+		}
+		changes, err := diffTable(ctx, client, appToken, tableID, t)
+		if err != nil {
+			return nil, fmt.Errorf("数据表 %s: %w", t.Name, err)
+		}
+		plan.Changes = append(plan.Changes, changes...)
+	}
+	for _, t := range tables {
+		name := feishu.StringValue(t.Name)
+		if !declared[name] {
+			plan.Changes = append(plan.Changes, &Change{
+				Action:      ActionDelete,
+				Kind:        KindTable,
+				Table:       name,
+				Name:        name,
+				Details:     []string{"删除数据表及其中的所有记录"},
+				Destructive: true,
+				tableID:     feishu.StringValue(t.TableId),
+			})
+		}
+	}
+	return plan, nil
+}
+
+func createTableChange(t *Table) *Change {
+	fields := make([]string, 0, len(t.Fields))
+	for _, f := range t.Fields {
+		fields = append(fields, fmt.Sprintf("%s（%s）", f.Name, f.fieldType))
+	}
+	details := []string{"字段: " + strings.Join(fields, "、")}
+	if len(t.Views) > 0 {
+		views := make([]string, 0, len(t.Views))
+		for _, v := range t.Views {
+			views = append(views, fmt.Sprintf("%s（%s）", v.Name, v.Type))
+		}
+		details = append(details, "视图: "+strings.Join(views, "、"))
+	}
+	return &Change{Action: ActionCreate, Kind: KindTable, Table: t.Name, Name: t.Name, Details: details, table: t}
+}
+
+// diffTable 对比已有数据表的字段和视图，变更顺序为：修改或新增字段、删除字段、新增视图、重建视图、删除视图
+func diffTable(ctx context.Context, client *feishu.MultiTableClient, appToken, tableID string, t *Table) ([]*Change, error) {
+	fields, err := client.ListFieldsContext(ctx, appToken, tableID)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*feishu.Field, len(fields))
+	var primary *feishu.Field
+	for _, f := range fields {
+		byName[f.Name] = f
+		if f.IsPrimary {
+			primary = f
+		}
+	}
+
+	var changes, deletes []*Change
+	matched := make(map[string]bool, len(fields))
+	for i, f := range t.Fields {
+		current := byName[f.Name]
+		if i == 0 && primary != nil {
+			switch {
+			case current == nil:
+				// schema 中的索引列在线上不存在时视为索引列改名
+				current = primary
+			case current != primary:
+				return nil, fmt.Errorf("字段 %s 不是索引列（当前索引列为 %s），请先在飞书中调整索引列", f.Name, primary.Name)
+			}
+		}
+		if current == nil {
+			spec, err := f.spec()
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, &Change{
+				Action:  ActionCreate,
+				Kind:    KindField,
+				Table:   t.Name,
+				Name:    f.Name,
+				Details: []string{"类型: " + f.fieldType.String()},
+				tableID: tableID,
+				spec:    spec,
+			})
+			continue
+		}
+		matched[current.ID] = true
+		change, err := diffField(f, current)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			change.Table, change.tableID = t.Name, tableID
+			changes = append(changes, change)
+		}
+	}
+	for _, f := range fields {
+		if !matched[f.ID] {
+			deletes = append(deletes, &Change{
+				Action:      ActionDelete,
+				Kind:        KindField,
+				Table:       t.Name,
+				Name:        f.Name,
+				Details:     []string{"删除字段及所有记录中该字段的值"},
+				Destructive: true,
+				tableID:     tableID,
+				id:          f.ID,
+			})
+		}
+	}
+	changes = append(changes, deletes...)
+
+	if len(t.Views) == 0 {
+		return changes, nil
+	}
+	views, err := client.ListViewsContext(ctx, appToken, tableID)
+	if err != nil {
+		return nil, err
+	}
+	return append(changes, diffViews(t, tableID, views)...), nil
+}
+
+// diffViews 对比视图，视图类型不能修改，类型不同时删除后重新创建
+func diffViews(t *Table, tableID string, views []*feishu.View) []*Change {
+	byName := make(map[string]*feishu.View, len(views))
+	for _, v := range views {
+		byName[v.Name] = v
+	}
+	declared := make(map[string]bool, len(t.Views))
+	var creates, recreates, deletes []*Change
+	for _, v := range t.Views {
+		declared[v.Name] = true
+		current := byName[v.Name]
+		switch {
+		case current == nil:
+			creates = append(creates, &Change{
+				Action:   ActionCreate,
+				Kind:     KindView,
+				Table:    t.Name,
+				Name:     v.Name,
+				Details:  []string{"类型: " + v.Type},
+				tableID:  tableID,
+				viewType: v.Type,
+			})
+		case current.Type != v.Type:
+			recreates = append(recreates, &Change{
+				Action:      ActionUpdate,
+				Kind:        KindView,
+				Table:       t.Name,
+				Name:        v.Name,
+				Details:     []string{fmt.Sprintf("类型: %s → %s（删除后重新创建，视图配置会丢失）", current.Type, v.Type)},
+				Destructive: true,
+				tableID:     tableID,
+				id:          current.ID,
+				viewType:    v.Type,
+			})
+		}
+	}
+	for _, v := range views {
+		if !declared[v.Name] {
+			deletes = append(deletes, &Change{
+				Action:      ActionDelete,
+				Kind:        KindView,
+				Table:       t.Name,
+				Name:        v.Name,
+				Details:     []string{"删除视图及其筛选、排序等配置"},
+				Destructive: true,
+				tableID:     tableID,
+				id:          v.ID,
+			})
+		}
+	}
+	return slices.Concat(creates, recreates, deletes)
+}
+
+// diffField 对比字段，没有差异时返回 nil。
+// 更新时以线上的定义为基础覆盖 schema 中声明的属性，未声明的属性保持不变。
+func diffField(f *Field, current *feishu.Field) (*Change, error) {
+	change := &Change{Action: ActionUpdate, Kind: KindField, Name: f.Name, id: current.ID}
+	if f.Name != current.Name {
+		change.Details = append(change.Details, fmt.Sprintf("名称: %s → %s", current.Name, f.Name))
+	}
+	typeChanged := f.fieldType != current.Type
+	if typeChanged {
+		change.Details = append(change.Details, fmt.Sprintf("类型: %s → %s（无法转换的值会丢失）", current.Type, f.fieldType))
+		change.Destructive = true
+	}
+	if f.UIType != "" && f.UIType != current.UIType {
+		change.Details = append(change.Details, fmt.Sprintf("展示类型: %s → %s", orNone(current.UIType), f.UIType))
+	}
+	if f.Description != "" && f.Description != current.Description {
+		change.Details = append(change.Details, fmt.Sprintf("描述: %s → %s", orNone(current.Description), f.Description))
+	}
+
+	desired, err := f.property()
+	if err != nil {
+		return nil, err
+	}
+	currentProperty := make(map[string]interface{})
+	if current.Property != nil {
+		if err := remarshal(current.Property, &currentProperty); err != nil {
+			return nil, fmt.Errorf("字段 %s 的属性无法解析: %w", current.Name, err)
+		}
+	}
+	keys := make([]string, 0, len(desired))
+	for k := range desired {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if k == "options" {
+			from, to := optionNames(currentProperty[k]), optionNames(desired[k])
+			if !slices.Equal(from, to) {
+				change.Details = append(change.Details, fmt.Sprintf("选项: %s → %s", orNone(strings.Join(from, "/")), strings.Join(to, "/")))
+				for _, name := range from {
+					if !slices.Contains(to, name) {
+						change.Destructive = true
+					}
+				}
+			}
+			continue
+		}
+		if !reflect.DeepEqual(currentProperty[k], desired[k]) {
+			change.Details = append(change.Details, fmt.Sprintf("%s: %s → %s", k, jsonString(currentProperty[k]), jsonString(desired[k])))
+		}
+	}
+	if len(change.Details) == 0 {
+		return nil, nil
+	}
+
+	change.spec = feishu.FieldSpec{
+		Name:        f.Name,
+		Type:        f.fieldType,
+		UIType:      f.UIType,
+		Description: f.Description,
+	}
+	if change.spec.Description == "" {
+		change.spec.Description = current.Description
+	}
+	currentOptions := currentProperty["options"]
+	property := desired
+	if !typeChanged {
+		if change.spec.UIType == "" {
+			change.spec.UIType = current.UIType
+		}
+		property = currentProperty
+		for k, v := range desired {
+			property[k] = v
+		}
+	}
+	if options, ok := desired["options"]; ok {
+		property["options"] = keepOptionIDs(options, currentOptions)
+	}
+	if len(property) > 0 {
+		if err := remarshal(property, &change.spec.Property); err != nil {
+			return nil, fmt.Errorf("字段 %s 的属性无效: %w", f.Name, err)
+		}
+	}
+	return change, nil
+}
+
+// keepOptionIDs 为与已有选项同名的选项带上原来的 ID 和颜色，使已选中该选项的记录不受影响
+func keepOptionIDs(desired, current interface{}) []interface{} {
+	existing := make(map[string]map[string]interface{})
+	items, _ := current.([]interface{})
+	for _, item := range items {
+		if option, ok := item.(map[string]interface{}); ok {
+			if name, ok := option["name"].(string); ok {
+				existing[name] = option
+			}
+		}
+	}
+	items, _ = desired.([]interface{})
+	out := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		option, ok := item.(map[string]interface{})
+		if !ok {
+			out = append(out, item)
+			continue
+		}
+		name, _ := option["name"].(string)
+		if old, ok := existing[name]; ok {
+			option = map[string]interface{}{"name": name, "id": old["id"], "color": old["color"]}
+		}
+		out = append(out, option)
+	}
+	return out
+}
+
+// optionNames 返回选项列表中的选项名称
+func optionNames(v interface{}) []string {
+	items, _ := v.([]interface{})
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		if option, ok := item.(map[string]interface{}); ok {
+			name, _ := option["name"].(string)
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// jsonString 以 JSON 格式显示属性值，未设置时显示 "（无）"
+func jsonString(v interface{}) string {
+	if v == nil {
+		return "（无）"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// orNone 空字符串显示为 "（无）"
+func orNone(s string) string {
+	if s == "" {
+		return "（无）"
+	}
+	return s
+}
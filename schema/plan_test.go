@@ -0,0 +1,205 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"feishu_bitable_demo/feishu"
+	"feishu_bitable_demo/feishu/fakeserver"
+)
+
+// productSchema 与 newProductApp 创建的数据表一致的 schema
+const productSchema = `
+tables:
+  - name: 产品列表
+    fields:
+      - name: 产品名称
+        type: text
+      - name: 状态
+        type: single_select
+        options: [在售, 下架]
+      - name: 价格
+        type: number
+      - name: 备注
+        type: text
+`
+
+// newProductApp 在模拟服务中创建一个只包含产品列表的多维表格
+func newProductApp(t *testing.T) (*fakeserver.Server, *feishu.MultiTableClient, string, string) {
+	t.Helper()
+	srv := fakeserver.New()
+	t.Cleanup(srv.Close)
+	client := srv.NewClient()
+	appToken, tableID, err := client.CreateAppAndTable("测试", "", "产品列表", feishu.Headers(
+		feishu.FieldSpec{Name: "产品名称", Type: feishu.FieldTypeText},
+		feishu.FieldSpec{Name: "状态", Type: feishu.FieldTypeSingleSelect, Property: feishu.SelectOptions("在售", "下架")},
+		feishu.FieldSpec{Name: "价格", Type: feishu.FieldTypeNumber},
+		feishu.FieldSpec{Name: "备注", Type: feishu.FieldTypeText},
+	))
+	if err != nil {
+		t.Fatalf("CreateAppAndTable: %v", err)
+	}
+	// 删除新建多维表格时自动创建的默认数据表
+	tables, err := client.ListTables(appToken)
+	if err != nil {
+		t.Fatalf("ListTables: %v", err)
+	}
+	for _, table := range tables {
+		if *table.TableId != tableID {
+			if err := client.DeleteTable(appToken, *table.TableId); err != nil {
+				t.Fatalf("DeleteTable: %v", err)
+			}
+		}
+	}
+	return srv, client, appToken, tableID
+}
+
+func mustParse(t *testing.T, data string) *Schema {
+	t.Helper()
+	s, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return s
+}
+
+func changeStrings(plan *Plan) []string {
+	changes := make([]string, 0, len(plan.Changes))
+	for _, c := range plan.Changes {
+		changes = append(changes, c.String())
+	}
+	return changes
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	_, client, appToken, _ := newProductApp(t)
+	plan, err := Diff(client, appToken, mustParse(t, productSchema))
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if len(plan.Changes) != 0 {
+		t.Fatalf("changes = %q, want none", changeStrings(plan))
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   []string
+	}{
+		{
+			name: "新增字段",
+			schema: strings.Replace(productSchema, "      - name: 备注\n        type: text\n",
+				"      - name: 备注\n        type: text\n      - name: 库存\n        type: number\n", 1),
+			want: []string{"+ 字段 产品列表.库存"},
+		},
+		{
+			name:   "修改字段类型",
+			schema: strings.Replace(productSchema, "name: 价格\n        type: number", "name: 价格\n        type: text", 1),
+			want:   []string{"~ 字段 产品列表.价格（破坏性）"},
+		},
+		{
+			name:   "修改描述",
+			schema: strings.Replace(productSchema, "name: 备注\n        type: text", "name: 备注\n        type: text\n        description: 内部备注", 1),
+			want:   []string{"~ 字段 产品列表.备注"},
+		},
+		{
+			name:   "索引列改名",
+			schema: strings.Replace(productSchema, "产品名称", "名称", 1),
+			want:   []string{"~ 字段 产品列表.名称"},
+		},
+		{
+			name:   "删除字段",
+			schema: strings.Replace(productSchema, "      - name: 备注\n        type: text\n", "", 1),
+			want:   []string{"- 字段 产品列表.备注（破坏性）"},
+		},
+		{
+			name:   "新增选项",
+			schema: strings.Replace(productSchema, "[在售, 下架]", "[在售, 预售, 下架]", 1),
+			want:   []string{"~ 字段 产品列表.状态"},
+		},
+		{
+			name:   "删除选项",
+			schema: strings.Replace(productSchema, "[在售, 下架]", "[在售]", 1),
+			want:   []string{"~ 字段 产品列表.状态（破坏性）"},
+		},
+		{
+			name: "新增和删除数据表",
+			schema: `
+tables:
+  - name: 订单
+    fields:
+      - name: 订单号
+        type: text
+`,
+			want: []string{"+ 数据表 订单", "- 数据表 产品列表（破坏性）"},
+		},
+		{
+			name:   "新增视图",
+			schema: productSchema + "    views:\n      - name: 表格\n      - name: 按状态\n        type: kanban\n",
+			want:   []string{"+ 视图 产品列表.按状态"},
+		},
+		{
+			name:   "修改视图类型",
+			schema: productSchema + "    views:\n      - name: 表格\n        type: kanban\n",
+			want:   []string{"~ 视图 产品列表.表格（破坏性）"},
+		},
+		{
+			name:   "删除视图",
+			schema: productSchema + "    views:\n      - name: 全部产品\n",
+			want:   []string{"+ 视图 产品列表.全部产品", "- 视图 产品列表.表格（破坏性）"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, client, appToken, _ := newProductApp(t)
+			plan, err := Diff(client, appToken, mustParse(t, tt.schema))
+			if err != nil {
+				t.Fatalf("Diff: %v", err)
+			}
+			if got := changeStrings(plan); !slices.Equal(got, tt.want) {
+				t.Errorf("changes = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffPrimaryFieldMismatch(t *testing.T) {
+	_, client, appToken, _ := newProductApp(t)
+	s := mustParse(t, `
+tables:
+  - name: 产品列表
+    fields:
+      - name: 备注
+        type: text
+      - name: 产品名称
+        type: text
+`)
+	if _, err := Diff(client, appToken, s); err == nil || !strings.Contains(err.Error(), "不是索引列") {
+		t.Fatalf("Diff error = %v, want 不是索引列", err)
+	}
+}
+
+func TestDiffManyTables(t *testing.T) {
+	_, client, appToken, _ := newProductApp(t)
+	var b strings.Builder
+	b.WriteString(productSchema)
+	for i := 1; i <= 24; i++ {
+		name := fmt.Sprintf("数据表%02d", i)
+		if _, err := client.CreateTable(appToken, name, nil); err != nil {
+			t.Fatalf("CreateTable: %v", err)
+		}
+		fmt.Fprintf(&b, "  - name: %s\n    fields:\n      - name: 多行文本\n        type: text\n", name)
+	}
+
+	plan, err := Diff(client, appToken, mustParse(t, b.String()))
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if len(plan.Changes) != 0 {
+		t.Fatalf("changes = %q, want none", changeStrings(plan))
+	}
+}
@@ -0,0 +1,216 @@
+// Package schema 以 YAML 文件声明多维表格的结构（数据表、字段、单选多选选项、视图），
+// 与线上的多维表格对比生成变更计划（Diff），再按计划新增、修改、删除字段和数据表（Apply）。
+//
+// 文件中未填写的属性不参与对比，也不会被修改；schema 中没有的字段、视图和数据表会被删除，
+// 删除以及修改字段类型、删除选项等可能丢失数据的变更为破坏性变更，需要显式允许才会执行。
+//
+//	tables:
+//	  - name: 产品列表
+//	    fields:
+//	      - name: 产品名称        # 第一个字段为索引列
+//	        type: text
+//	      - name: 状态
+//	        type: single_select
+//	        options: [在售, 预售, 下架]
+//	    views:
+//	      - name: 全部产品
+//	      - name: 按状态
+//	        type: kanban
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"feishu_bitable_demo/feishu"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema 多维表格的结构定义
+type Schema struct {
+	Tables []*Table `yaml:"tables"`
+}
+
+// Table 数据表定义，字段和视图按名称与线上的数据表对应
+type Table struct {
+	Name   string   `yaml:"name"`
+	Fields []*Field `yaml:"fields"` // 第一个字段为索引列
+	Views  []*View  `yaml:"views"`  // 为空时不管理视图
+}
+
+// Field 字段定义，属性为空表示不管理
+type Field struct {
+	Name         string   `yaml:"name"`
+	Type         string   `yaml:"type"`    // 类型名称，如 text、single_select，也可以填写中文名称或类型编号
+	UIType       string   `yaml:"ui_type"` // 界面展示类型，如 Currency、Progress
+	Description  string   `yaml:"description"`
+	Options      []string `yaml:"options"`       // 单选、多选字段的选项
+	Formatter    string   `yaml:"formatter"`     // 数字、货币、公式字段的显示格式，如 "0.00"
+	CurrencyCode string   `yaml:"currency_code"` // 货币字段的币种，如 CNY
+	DateFormat   string   `yaml:"date_format"`   // 日期字段的显示格式，如 yyyy/MM/dd
+
+	// Property 其他字段属性，格式同飞书字段编辑指南，如 {"formula_expression": "..."}
+	Property map[string]interface{} `yaml:"property"`
+
+	fieldType feishu.FieldType
+}
+
+// View 视图定义
+type View struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"` // feishu.ViewTypeGrid 等，默认为表格视图
+}
+
+// Load 读取并校验 schema 文件
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取 schema 文件失败: %w", err)
+	}
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Parse 解析并校验 YAML 格式的 schema
+func Parse(data []byte) (*Schema, error) {
+	var s Schema
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("解析 schema 失败: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate 校验名称不为空且不重复、字段类型有效、选项只用于单选和多选字段
+func (s *Schema) Validate() error {
+	if len(s.Tables) == 0 {
+		return errors.New("schema 中没有数据表")
+	}
+	tables := make(map[string]bool, len(s.Tables))
+	for i, t := range s.Tables {
+		if t == nil || t.Name == "" {
+			return fmt.Errorf("第 %d 张数据表缺少 name", i+1)
+		}
+		if tables[t.Name] {
+			return fmt.Errorf("数据表 %s 重复", t.Name)
+		}
+		tables[t.Name] = true
+		if err := t.validate(); err != nil {
+			return fmt.Errorf("数据表 %s: %w", t.Name, err)
+		}
+	}
+	return nil
+}
+
+func (t *Table) validate() error {
+	if len(t.Fields) == 0 {
+		return errors.New("至少需要一个字段")
+	}
+	fields := make(map[string]bool, len(t.Fields))
+	for i, f := range t.Fields {
+		if f == nil || f.Name == "" {
+			return fmt.Errorf("第 %d 个字段缺少 name", i+1)
+		}
+		if fields[f.Name] {
+			return fmt.Errorf("字段 %s 重复", f.Name)
+		}
+		fields[f.Name] = true
+		fieldType, err := feishu.ParseFieldType(f.Type)
+		if err != nil {
+			return fmt.Errorf("字段 %s: %w", f.Name, err)
+		}
+		f.fieldType = fieldType
+		if len(f.Options) > 0 && !isSelect(fieldType) {
+			return fmt.Errorf("字段 %s 不是单选或多选字段，不能设置 options", f.Name)
+		}
+		if _, err := f.spec(); err != nil {
+			return err
+		}
+	}
+	views := make(map[string]bool, len(t.Views))
+	for i, v := range t.Views {
+		if v == nil || v.Name == "" {
+			return fmt.Errorf("第 %d 个视图缺少 name", i+1)
+		}
+		if views[v.Name] {
+			return fmt.Errorf("视图 %s 重复", v.Name)
+		}
+		views[v.Name] = true
+		if v.Type == "" {
+			v.Type = feishu.ViewTypeGrid
+		}
+	}
+	return nil
+}
+
+// property 返回 schema 中声明的字段属性，键与飞书字段属性的 JSON 格式一致
+func (f *Field) property() (map[string]interface{}, error) {
+	p := make(map[string]interface{}, len(f.Property)+4)
+	for k, v := range f.Property {
+		p[k] = v
+	}
+	if len(f.Options) > 0 {
+		options := make([]interface{}, 0, len(f.Options))
+		for i, name := range f.Options {
+			options = append(options, map[string]interface{}{"name": name, "color": i % 55})
+		}
+		p["options"] = options
+	}
+	if f.Formatter != "" {
+		p["formatter"] = f.Formatter
+	}
+	if f.CurrencyCode != "" {
+		p["currency_code"] = f.CurrencyCode
+	}
+	if f.DateFormat != "" {
+		p["date_formatter"] = f.DateFormat
+	}
+	// 经过 JSON 转换，使数字等值的类型与接口返回的属性一致，便于比较
+	var out map[string]interface{}
+	if err := remarshal(p, &out); err != nil {
+		return nil, fmt.Errorf("字段 %s 的属性无效: %w", f.Name, err)
+	}
+	return out, nil
+}
+
+// spec 转换为新建字段时使用的字段定义
+func (f *Field) spec() (feishu.FieldSpec, error) {
+	spec := feishu.FieldSpec{
+		Name:        f.Name,
+		Type:        f.fieldType,
+		UIType:      f.UIType,
+		Description: f.Description,
+	}
+	p, err := f.property()
+	if err != nil {
+		return spec, err
+	}
+	if len(p) > 0 {
+		if err := remarshal(p, &spec.Property); err != nil {
+			return spec, fmt.Errorf("字段 %s 的属性无效: %w", f.Name, err)
+		}
+	}
+	return spec, nil
+}
+
+// isSelect 判断是否为单选或多选字段
+func isSelect(t feishu.FieldType) bool {
+	return t == feishu.FieldTypeSingleSelect || t == feishu.FieldTypeMultiSelect
+}
+
+// remarshal 经过 JSON 将 v 转换到 out
+func remarshal(v, out interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}